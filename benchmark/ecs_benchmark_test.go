@@ -71,6 +71,78 @@ func BenchmarkSpawn(b *testing.B) {
 	})
 }
 
+func BenchmarkSpawnBatch(b *testing.B) {
+	for _, size := range []uint{10, 100, 1_000, 10_000} {
+		b.Run(fmt.Sprintf("Spawn-TwoComponents-Size-%d", size), func(b *testing.B) {
+			for b.Loop() {
+				world := ecs.NewDefaultWorld()
+				for range size {
+					ecs.Spawn(world, &emptyComponentA{}, &emptyComponentB{})
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("SpawnBatch-TwoComponents-Size-%d", size), func(b *testing.B) {
+			for b.Loop() {
+				world := ecs.NewDefaultWorld()
+				ecs.SpawnBatch(world, size, &emptyComponentA{}, &emptyComponentB{})
+			}
+		})
+	}
+}
+
+func BenchmarkSpawner(b *testing.B) {
+	b.Run("Spawn-TwoComponents", func(b *testing.B) {
+		world := ecs.NewDefaultWorld()
+
+		for b.Loop() {
+			ecs.Spawn(world, &emptyComponentA{}, &emptyComponentB{})
+		}
+	})
+
+	b.Run("Spawner-TwoComponents", func(b *testing.B) {
+		world := ecs.NewDefaultWorld()
+		spawner, err := ecs.NewSpawner(world, &emptyComponentA{}, &emptyComponentB{})
+		if err != nil {
+			b.FailNow()
+		}
+
+		for b.Loop() {
+			spawner.Spawn()
+		}
+	})
+}
+
+func BenchmarkSnapshotBinary(b *testing.B) {
+	const size = 500_000
+
+	world := ecs.NewDefaultWorld()
+	if _, err := ecs.SpawnBatch(world, size, &componentWithValue{value: 1}, &emptyComponentA{}); err != nil {
+		b.FailNow()
+	}
+
+	b.Run(fmt.Sprintf("Snapshot-Size-%d", size), func(b *testing.B) {
+		for b.Loop() {
+			if _, err := ecs.SnapshotBinary(world); err != nil {
+				b.FailNow()
+			}
+		}
+	})
+
+	b.Run(fmt.Sprintf("Restore-Size-%d", size), func(b *testing.B) {
+		data, err := ecs.SnapshotBinary(world)
+		if err != nil {
+			b.FailNow()
+		}
+
+		for b.Loop() {
+			if err := ecs.RestoreBinary(world, data); err != nil {
+				b.FailNow()
+			}
+		}
+	})
+}
+
 func BenchmarkInsert(b *testing.B) {
 	for _, size := range []int{10, 100, 1_000, 10_000} {
 		setupWorld := func() *ecs.World {
@@ -716,6 +788,50 @@ func BenchmarkQuery(b *testing.B) {
 	}
 }
 
+func BenchmarkQueryIter(b *testing.B) {
+	for _, size := range []int{10, 100, 1_000, 10_000} {
+		world := ecs.NewDefaultWorld()
+
+		for range size {
+			if err := fillWorld(world); err != nil {
+				b.FailNow()
+			}
+		}
+
+		b.Run(fmt.Sprintf("Query2-Materialized-Size-%d", size), func(b *testing.B) {
+			query := ecs.Query2[*componentWithValue, emptyComponentA, ecs.Default]{}
+
+			err := query.Prepare(world, nil)
+			if err != nil {
+				b.FailNow()
+			}
+
+			for b.Loop() {
+				query.Exec(world)
+				query.Iter(func(entityId ecs.EntityId, c *componentWithValue, a emptyComponentA) {
+					c.value++
+				})
+			}
+		})
+
+		b.Run(fmt.Sprintf("Query2-ZeroCopy-Size-%d", size), func(b *testing.B) {
+			query := ecs.Query2[*componentWithValue, emptyComponentA, ecs.ZeroCopy]{}
+
+			err := query.Prepare(world, nil)
+			if err != nil {
+				b.FailNow()
+			}
+
+			for b.Loop() {
+				query.Exec(world)
+				query.Iter(func(entityId ecs.EntityId, c *componentWithValue, a emptyComponentA) {
+					c.value++
+				})
+			}
+		})
+	}
+}
+
 // Fills the world with 7 different archetypes
 func fillWorld(world *ecs.World) error {
 	if _, err := ecs.Spawn(world, &emptyComponentA{}); err != nil {
@@ -4,4 +4,11 @@ import "errors"
 
 var (
 	ErrScheduleTypeNotFound error = errors.New("schedule type not found")
+
+	ErrRollbackNotEnabled   error = errors.New("rollback is not enabled")
+	ErrRollbackTickNotFound error = errors.New("state of tick is not kept")
+
+	ErrReplicationClientTooSlow    error = errors.New("replication client can not keep up")
+	ErrReplicationMessageTooLarge  error = errors.New("replication message is too large")
+	ErrReplicationConnectionClosed error = errors.New("replication connection is closed")
 )
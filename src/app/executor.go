@@ -43,3 +43,39 @@ func (executor *ConsecutiveExecutor) ProcessEvents(currentTick uint) {
 		executor.eventStorage.ProcessEvents(startupSystem.Id(), currentTick)
 	}
 }
+
+// ParallelExecutor runs systems that do not conflict with each other in parallel. See [ecs.ScheduleSystems.ExecParallel]
+// for when systems conflict. Schedules still run one after another.
+type ParallelExecutor struct {
+	systems []*ecs.ScheduleSystems
+
+	world        *ecs.World
+	logger       Logger
+	appName      string
+	eventStorage *ecs.EventStorage
+}
+
+func (executor *ParallelExecutor) Load(systems []*ecs.ScheduleSystems, world *ecs.World, logger Logger, appName string) {
+	executor.systems = systems
+	executor.world = world
+	executor.logger = logger
+	executor.appName = appName
+	executor.eventStorage = world.Events()
+}
+
+func (executor *ParallelExecutor) Run(currentTick uint) {
+	for _, scheduleSystems := range executor.systems {
+		errors := scheduleSystems.ExecParallel(executor.world, executor.world.OuterWorlds(), executor.eventStorage, currentTick)
+		for _, err := range errors {
+			executor.logger.Error("%s - system returned error: %v", executor.appName, err)
+		}
+	}
+
+	executor.world.Process()
+}
+
+func (executor *ParallelExecutor) ProcessEvents(currentTick uint) {
+	for _, startupSystem := range executor.systems {
+		executor.eventStorage.ProcessEvents(startupSystem.Id(), currentTick)
+	}
+}
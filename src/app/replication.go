@@ -0,0 +1,346 @@
+package app
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lucdrenth/murphecs/src/ecs"
+)
+
+const (
+	// replicationMessageBufferSize is the number of messages that are kept for a client that did not receive them yet.
+	replicationMessageBufferSize = 128
+
+	// replicationMaxMessageSize limits the size of a single message, so that invalid data can not cause huge
+	// allocations on the client.
+	replicationMaxMessageSize = 256 << 20
+)
+
+// ReplicationServer sends the changes of the replicated components (see [ecs.Replicated]) of a world to its clients.
+// Use [ReplicationServerFeature] to send the changes of a SubApp each tick, and [ReplicationClient] to receive them.
+//
+// Each message is an [ecs.WorldDelta] that is encoded with [ecs.WorldDelta.MarshalBinary] and prefixed with its size
+// as uvarint. The first message to a client contains all replicated entities. Changes are found through change
+// detection, see [ecs.DeltaTracker.Next].
+type ReplicationServer struct {
+	mutex   sync.Mutex
+	clients []*ReplicationConnection
+}
+
+// ReplicationConnection is the connection of a [ReplicationServer] to one of its clients.
+type ReplicationConnection struct {
+	conn      net.Conn
+	tracker   *ecs.DeltaTracker
+	relevance atomic.Pointer[ecs.Relevance]
+	messages  chan []byte
+
+	// isClosed is set when writing to conn failed. messages is only closed by the server.
+	isClosed atomic.Bool
+}
+
+func NewReplicationServer() *ReplicationServer {
+	return &ReplicationServer{}
+}
+
+// AddClient starts replicating to conn, starting with the next replication. This can be called from any goroutine,
+// such as one that accepts connections from a [net.Listener]. Use the returned connection to decide which entities
+// are relevant to the client.
+func (server *ReplicationServer) AddClient(conn net.Conn) *ReplicationConnection {
+	client := &ReplicationConnection{
+		conn:     conn,
+		tracker:  ecs.NewDeltaTracker(ecs.IsReplicated),
+		messages: make(chan []byte, replicationMessageBufferSize),
+	}
+	go client.write()
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.clients = append(server.clients, client)
+
+	return client
+}
+
+// NumberOfClients returns the number of clients that are being replicated to.
+func (server *ReplicationServer) NumberOfClients() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return len(server.clients)
+}
+
+// Close closes the connections to all clients.
+func (server *ReplicationServer) Close() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for _, client := range server.clients {
+		client.stop()
+	}
+	server.clients = nil
+}
+
+// replicate sends the changes of world since the previous replication to each client. Clients of which the connection
+// got closed are removed.
+//
+// Can return the following errors:
+//   - Returns an ErrReplicationClientTooSlow error when a client did not receive its previous messages yet. Its
+//     connection is closed.
+//   - Any error that [ecs.DeltaTracker.Next] returns.
+func (server *ReplicationServer) replicate(world *ecs.World) error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var result error
+	clients := server.clients[:0]
+	for _, client := range server.clients {
+		if client.isClosed.Load() {
+			client.stop()
+			continue
+		}
+
+		client.tracker.SetRelevance(client.relevance.Load())
+		delta, err := client.tracker.Next(world)
+		if err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to replicate to %s: %w", client.conn.RemoteAddr(), err))
+			clients = append(clients, client)
+			continue
+		}
+
+		if delta.IsEmpty() {
+			clients = append(clients, client)
+			continue
+		}
+
+		data, err := delta.MarshalBinary()
+		if err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to replicate to %s: %w", client.conn.RemoteAddr(), err))
+			clients = append(clients, client)
+			continue
+		}
+
+		message := binary.AppendUvarint(make([]byte, 0, len(data)+binary.MaxVarintLen64), uint64(len(data)))
+		message = append(message, data...)
+
+		select {
+		case client.messages <- message:
+			clients = append(clients, client)
+		default:
+			client.stop()
+			result = errors.Join(result, fmt.Errorf("%w: %s", ErrReplicationClientTooSlow, client.conn.RemoteAddr()))
+		}
+	}
+
+	clear(server.clients[len(clients):])
+	server.clients = clients
+
+	return result
+}
+
+// SetRelevance makes the client only receive the entities that are relevant according to relevance, starting with
+// the next replication. Entities that become relevant are spawned on the client, and entities that are no longer
+// relevant are despawned on the client. All replicated entities are relevant if relevance is nil, which is the
+// default. This can be called from any goroutine, such as from a system once the avatar of the client got spawned.
+func (client *ReplicationConnection) SetRelevance(relevance *ecs.Relevance) {
+	client.relevance.Store(relevance)
+}
+
+// RemoteAddr returns the address of the client.
+func (client *ReplicationConnection) RemoteAddr() net.Addr {
+	return client.conn.RemoteAddr()
+}
+
+// write sends the messages to the client until the connection gets closed.
+func (client *ReplicationConnection) write() {
+	for message := range client.messages {
+		if _, err := client.conn.Write(message); err != nil {
+			client.isClosed.Store(true)
+			client.conn.Close()
+			return
+		}
+	}
+}
+
+// stop closes the connection. Must only be called once, by the server.
+func (client *ReplicationConnection) stop() {
+	client.isClosed.Store(true)
+	client.conn.Close()
+	close(client.messages)
+}
+
+// ReplicationServerFeature sends the changes of the replicated components of the world of the SubApp to the clients
+// of Server, each time Schedule runs. Schedule should be the last repeated schedule, so that the clients receive the
+// state at the end of each tick.
+type ReplicationServerFeature struct {
+	Feature
+	Server   *ReplicationServer
+	Schedule ecs.Schedule
+}
+
+func (feature *ReplicationServerFeature) Init() {
+	feature.AddSystem(feature.Schedule, feature.Server.replicate)
+}
+
+// ReplicationClient receives the changes that a [ReplicationServer] sends, and applies them to a world. Use
+// [ReplicationClientFeature] to apply them to a SubApp each tick.
+//
+// Replicated entities get new entity ids on the client. Entity ids in the replicated components are rewritten to the
+// entity ids of the client, see [ecs.ApplyDelta]. The replicated component types must be known by the world of the
+// client, see [ecs.RegisterComponent].
+type ReplicationClient struct {
+	conn net.Conn
+
+	mutex         sync.Mutex
+	pending       []ecs.WorldDelta
+	err           error // the error that stopped receiving
+	isErrReported bool
+
+	entityMutex sync.RWMutex
+	entityMap   map[ecs.EntityId]ecs.EntityId // maps the entity ids of the server to the entity ids of the client
+}
+
+// NewReplicationClient starts receiving changes from conn.
+func NewReplicationClient(conn net.Conn) *ReplicationClient {
+	client := &ReplicationClient{
+		conn:      conn,
+		entityMap: map[ecs.EntityId]ecs.EntityId{},
+	}
+	go client.receive()
+
+	return client
+}
+
+// Entity returns the entity id on the client of an entity of the server.
+func (client *ReplicationClient) Entity(serverEntity ecs.EntityId) (ecs.EntityId, bool) {
+	client.entityMutex.RLock()
+	defer client.entityMutex.RUnlock()
+
+	entity, exists := client.entityMap[serverEntity]
+	return entity, exists
+}
+
+// NumberOfEntities returns the number of replicated entities.
+func (client *ReplicationClient) NumberOfEntities() int {
+	client.entityMutex.RLock()
+	defer client.entityMutex.RUnlock()
+
+	return len(client.entityMap)
+}
+
+// Err returns the error that stopped the client from receiving changes, or nil while it is receiving. Returns
+// [io.EOF] if the server closed the connection.
+func (client *ReplicationClient) Err() error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	return client.err
+}
+
+// Close closes the connection to the server.
+func (client *ReplicationClient) Close() error {
+	return client.conn.Close()
+}
+
+// receive reads messages from the connection until it gets closed.
+func (client *ReplicationClient) receive() {
+	reader := bufio.NewReader(client.conn)
+
+	for {
+		delta, err := readReplicationMessage(reader)
+		if err != nil {
+			client.stop(err)
+			return
+		}
+
+		client.mutex.Lock()
+		client.pending = append(client.pending, delta)
+		client.mutex.Unlock()
+	}
+}
+
+func readReplicationMessage(reader *bufio.Reader) (ecs.WorldDelta, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return ecs.WorldDelta{}, err
+	}
+	if size > replicationMaxMessageSize {
+		return ecs.WorldDelta{}, fmt.Errorf("%w: %d bytes", ErrReplicationMessageTooLarge, size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return ecs.WorldDelta{}, err
+	}
+
+	delta := ecs.WorldDelta{}
+	if err := delta.UnmarshalBinary(data); err != nil {
+		return ecs.WorldDelta{}, err
+	}
+
+	return delta, nil
+}
+
+// stop closes the connection because of err, unless it was stopped already.
+func (client *ReplicationClient) stop(err error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.err == nil {
+		client.err = err
+		client.conn.Close()
+	}
+}
+
+// apply applies the changes that got received since the previous call to world.
+//
+// Can return the following errors:
+//   - Returns an ErrReplicationConnectionClosed error, once, when the client stopped receiving changes.
+//   - Any error that [ecs.ApplyDelta] returns. The connection is then closed, because the following changes can not
+//     be applied anymore.
+func (client *ReplicationClient) apply(world *ecs.World) error {
+	client.mutex.Lock()
+	pending := client.pending
+	client.pending = nil
+	err := client.err
+	isErrReported := client.isErrReported
+	client.isErrReported = err != nil
+	client.mutex.Unlock()
+
+	client.entityMutex.Lock()
+	defer client.entityMutex.Unlock()
+
+	for _, delta := range pending {
+		if err := ecs.ApplyDelta(world, delta, client.entityMap); err != nil {
+			err = fmt.Errorf("failed to apply replicated changes: %w", err)
+			client.stop(err)
+
+			client.mutex.Lock()
+			client.isErrReported = true
+			client.mutex.Unlock()
+			return err
+		}
+	}
+
+	if err != nil && !isErrReported {
+		return fmt.Errorf("%w: %w", ErrReplicationConnectionClosed, err)
+	}
+
+	return nil
+}
+
+// ReplicationClientFeature applies the changes that Client received to the world of the SubApp, each time Schedule
+// runs. Schedule should be the first repeated schedule, so that the other systems see the received state.
+type ReplicationClientFeature struct {
+	Feature
+	Client   *ReplicationClient
+	Schedule ecs.Schedule
+}
+
+func (feature *ReplicationClientFeature) Init() {
+	feature.AddSystem(feature.Schedule, feature.Client.apply)
+}
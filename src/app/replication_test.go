@@ -0,0 +1,250 @@
+package app
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucdrenth/murphecs/src/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplication(t *testing.T) {
+	type replicatedPosition struct {
+		ecs.Component
+		ecs.Replicated
+		X      int
+		Target ecs.EntityId
+	}
+	type secret struct {
+		ecs.Component
+		Value int
+	}
+
+	newClientWorld := func() *ecs.World {
+		world := ecs.NewDefaultWorld()
+		ecs.RegisterComponent[replicatedPosition](world)
+		return world
+	}
+
+	// receive applies the received changes to world until condition returns true
+	receive := func(t *testing.T, client *ReplicationClient, world *ecs.World, condition func() bool) {
+		assert.Eventually(t, func() bool {
+			assert.NoError(t, client.apply(world))
+			return condition()
+		}, time.Second, time.Millisecond)
+	}
+
+	t.Run("replicates the replicated components to a client", func(t *testing.T) {
+		assert := assert.New(t)
+		serverConn, clientConn := net.Pipe()
+		server := NewReplicationServer()
+		defer server.Close()
+		server.AddClient(serverConn)
+		client := NewReplicationClient(clientConn)
+		defer client.Close()
+
+		world := ecs.NewDefaultWorld()
+		clientWorld := newClientWorld()
+
+		target, err := ecs.Spawn(world, &replicatedPosition{X: 1})
+		assert.NoError(err)
+		entity, err := ecs.Spawn(world, &replicatedPosition{X: 2, Target: target}, &secret{Value: 3})
+		assert.NoError(err)
+		_, err = ecs.Spawn(world, &secret{Value: 4})
+		assert.NoError(err)
+
+		assert.NoError(server.replicate(world))
+		receive(t, client, clientWorld, func() bool { return client.NumberOfEntities() == 2 })
+		assert.Equal(2, clientWorld.CountEntities())
+
+		clientEntity, exists := client.Entity(entity)
+		assert.True(exists)
+		clientTarget, exists := client.Entity(target)
+		assert.True(exists)
+		position, err := ecs.Get1[replicatedPosition](clientWorld, clientEntity)
+		assert.NoError(err)
+		assert.Equal(2, position.X)
+		assert.Equal(clientTarget, position.Target)
+		hasSecret, err := ecs.HasComponent[secret](clientWorld, clientEntity)
+		assert.NoError(err)
+		assert.False(hasSecret)
+
+		serverPosition, err := ecs.GetMut[replicatedPosition](world, entity)
+		assert.NoError(err)
+		serverPosition.Ptr().X = 5
+		assert.NoError(ecs.Despawn(world, target))
+
+		assert.NoError(server.replicate(world))
+		receive(t, client, clientWorld, func() bool { return client.NumberOfEntities() == 1 })
+		assert.Equal(1, clientWorld.CountEntities())
+
+		position, err = ecs.Get1[replicatedPosition](clientWorld, clientEntity)
+		assert.NoError(err)
+		assert.Equal(5, position.X)
+	})
+
+	t.Run("only replicates the entities that are relevant to a client", func(t *testing.T) {
+		assert := assert.New(t)
+		serverConn, clientConn := net.Pipe()
+		server := NewReplicationServer()
+		defer server.Close()
+		connection := server.AddClient(serverConn)
+		client := NewReplicationClient(clientConn)
+		defer client.Close()
+
+		world := ecs.NewDefaultWorld()
+		clientWorld := newClientWorld()
+
+		visible, err := ecs.Spawn(world, &replicatedPosition{X: 1})
+		assert.NoError(err)
+		hidden, err := ecs.Spawn(world, &replicatedPosition{X: 2}, &secret{})
+		assert.NoError(err)
+
+		relevance, err := ecs.NewRelevance[ecs.Without[secret]](world)
+		assert.NoError(err)
+		connection.SetRelevance(relevance)
+
+		assert.NoError(server.replicate(world))
+		receive(t, client, clientWorld, func() bool { return client.NumberOfEntities() == 1 })
+		_, exists := client.Entity(visible)
+		assert.True(exists)
+
+		// entities that become relevant are spawned on the client
+		assert.NoError(ecs.Remove1[secret](world, hidden))
+		assert.NoError(server.replicate(world))
+		receive(t, client, clientWorld, func() bool { return client.NumberOfEntities() == 2 })
+		_, exists = client.Entity(hidden)
+		assert.True(exists)
+
+		// entities that are no longer relevant are despawned on the client
+		assert.NoError(ecs.Insert(world, visible, &secret{}))
+		assert.NoError(server.replicate(world))
+		receive(t, client, clientWorld, func() bool { return client.NumberOfEntities() == 1 })
+		_, exists = client.Entity(visible)
+		assert.False(exists)
+		assert.Equal(1, clientWorld.CountEntities())
+	})
+
+	t.Run("replicates through features over a localhost connection", func(t *testing.T) {
+		const update ecs.Schedule = "Update"
+		assert := assert.New(t)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(err)
+		defer listener.Close()
+
+		server := NewReplicationServer()
+		defer server.Close()
+		go func() {
+			conn, err := listener.Accept()
+			if err == nil {
+				server.AddClient(conn)
+			}
+		}()
+
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assert.NoError(err)
+		client := NewReplicationClient(conn)
+		defer client.Close()
+		assert.Eventually(func() bool { return server.NumberOfClients() == 1 }, time.Second, time.Millisecond)
+
+		logger := TestLogger{}
+		serverApp, err := New(&logger, ecs.DefaultWorldConfigs())
+		assert.NoError(err)
+		serverApp.AddSchedule(update, ScheduleOptions{ScheduleType: ScheduleTypeRepeating})
+		serverApp.AddSystem(update, func(world *ecs.World) error {
+			_, err := ecs.Spawn(world, &replicatedPosition{X: 1})
+			return err
+		})
+		serverApp.AddFeature(&ReplicationServerFeature{Server: server, Schedule: update})
+		serverApp.UseNTimesRunner(3)
+
+		clientApp, err := New(&logger, ecs.DefaultWorldConfigs())
+		assert.NoError(err)
+		ecs.RegisterComponent[replicatedPosition](clientApp.World())
+		clientApp.AddSchedule(update, ScheduleOptions{ScheduleType: ScheduleTypeRepeating})
+		clientApp.AddFeature(&ReplicationClientFeature{Client: client, Schedule: update})
+		clientApp.UseNTimesRunner(1)
+
+		isDoneChannel := make(chan bool)
+		go serverApp.Run(make(chan struct{}), isDoneChannel)
+		<-isDoneChannel
+
+		assert.Eventually(func() bool {
+			client.mutex.Lock()
+			defer client.mutex.Unlock()
+			return len(client.pending) == 3
+		}, time.Second, time.Millisecond)
+
+		go clientApp.Run(make(chan struct{}), isDoneChannel)
+		<-isDoneChannel
+
+		assert.Equal(3, clientApp.World().CountEntities())
+		assert.Equal(uint(0), logger.NumberOfErrorLogs)
+	})
+
+	t.Run("closes the connection of a client that can not keep up", func(t *testing.T) {
+		assert := assert.New(t)
+		serverConn, clientConn := net.Pipe()
+		defer clientConn.Close()
+		server := NewReplicationServer()
+		server.AddClient(serverConn)
+
+		world := ecs.NewDefaultWorld()
+		entity, err := ecs.Spawn(world, &replicatedPosition{})
+		assert.NoError(err)
+
+		// nothing reads from clientConn, so the messages pile up
+		for range replicationMessageBufferSize + 2 {
+			position, err := ecs.GetMut[replicatedPosition](world, entity)
+			assert.NoError(err)
+			position.Ptr().X++
+
+			if err := server.replicate(world); err != nil {
+				assert.ErrorIs(err, ErrReplicationClientTooSlow)
+				break
+			}
+		}
+
+		assert.Equal(0, server.NumberOfClients())
+	})
+
+	t.Run("removes clients that disconnected", func(t *testing.T) {
+		assert := assert.New(t)
+		serverConn, clientConn := net.Pipe()
+		server := NewReplicationServer()
+		server.AddClient(serverConn)
+		assert.NoError(clientConn.Close())
+
+		world := ecs.NewDefaultWorld()
+		entity, err := ecs.Spawn(world, &replicatedPosition{})
+		assert.NoError(err)
+
+		assert.Eventually(func() bool {
+			position, err := ecs.GetMut[replicatedPosition](world, entity)
+			assert.NoError(err)
+			position.Ptr().X++
+
+			assert.NoError(server.replicate(world))
+			return server.NumberOfClients() == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("reports once that the server closed the connection", func(t *testing.T) {
+		assert := assert.New(t)
+		serverConn, clientConn := net.Pipe()
+		server := NewReplicationServer()
+		server.AddClient(serverConn)
+		client := NewReplicationClient(clientConn)
+		world := newClientWorld()
+
+		server.Close()
+		assert.Eventually(func() bool { return client.Err() != nil }, time.Second, time.Millisecond)
+		assert.ErrorIs(client.Err(), io.EOF)
+
+		assert.ErrorIs(client.apply(world), ErrReplicationConnectionClosed)
+		assert.NoError(client.apply(world))
+	})
+}
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lucdrenth/murphecs/src/ecs"
+)
+
+// rollbackHistory keeps the state of a SubApp after each of its last ticks in a ring buffer, so that the SubApp can
+// be rolled back to any of those ticks. See [SubApp.EnableRollback].
+type rollbackHistory struct {
+	// snapshots[tick % len(snapshots)] is the snapshot of tick, if its tick matches.
+	snapshots []tickSnapshot
+
+	// The earliest tick that got requested with [SubApp.RequestRollback] since the last rollback.
+	requestMutex  sync.Mutex
+	requestedTick uint
+	hasRequest    bool
+}
+
+// tickSnapshot is the state of a SubApp after running a tick.
+type tickSnapshot struct {
+	tick  uint
+	delta float64 // the delta time with which the tick ran
+	isSet bool
+
+	// hasState is false if the world got rolled back to an earlier tick, after which the state of this tick is no
+	// longer valid. The delta time is kept, so that this tick can be resimulated with the same delta time.
+	hasState bool
+	world    []byte
+	events   ecs.EventsSnapshot
+}
+
+func newRollbackHistory(numberOfTicks uint) *rollbackHistory {
+	return &rollbackHistory{
+		snapshots: make([]tickSnapshot, numberOfTicks),
+	}
+}
+
+// capture stores the current state of world as the state after tick.
+func (history *rollbackHistory) capture(world *ecs.World, tick uint, delta float64) error {
+	snapshot := &history.snapshots[tick%uint(len(history.snapshots))]
+	*snapshot = tickSnapshot{
+		tick:  tick,
+		delta: delta,
+		isSet: true,
+	}
+
+	world.Mutex.RLock()
+	defer world.Mutex.RUnlock()
+
+	worldSnapshot, err := ecs.SnapshotBinary(world)
+	if err != nil {
+		return err
+	}
+
+	snapshot.hasState = true
+	snapshot.world = worldSnapshot
+	snapshot.events = world.Events().Snapshot()
+
+	return nil
+}
+
+// get returns the snapshot of tick.
+func (history *rollbackHistory) get(tick uint) (*tickSnapshot, bool) {
+	snapshot := &history.snapshots[tick%uint(len(history.snapshots))]
+	if !snapshot.isSet || snapshot.tick != tick {
+		return nil, false
+	}
+
+	return snapshot, true
+}
+
+// delta returns the delta time with which tick ran.
+func (history *rollbackHistory) delta(tick uint) (float64, bool) {
+	snapshot, exists := history.get(tick)
+	if !exists {
+		return 0, false
+	}
+
+	return snapshot.delta, true
+}
+
+// invalidateAfter marks the state of all ticks after tick as invalid.
+func (history *rollbackHistory) invalidateAfter(tick uint) {
+	for i := range history.snapshots {
+		if history.snapshots[i].tick > tick {
+			history.snapshots[i].hasState = false
+			history.snapshots[i].world = nil
+			history.snapshots[i].events = ecs.EventsSnapshot{}
+		}
+	}
+}
+
+func (history *rollbackHistory) request(tick uint) {
+	history.requestMutex.Lock()
+	defer history.requestMutex.Unlock()
+
+	if !history.hasRequest || tick < history.requestedTick {
+		history.requestedTick = tick
+		history.hasRequest = true
+	}
+}
+
+// takeRequest returns the requested tick and clears the request.
+func (history *rollbackHistory) takeRequest() (uint, bool) {
+	history.requestMutex.Lock()
+	defer history.requestMutex.Unlock()
+
+	tick, hasRequest := history.requestedTick, history.hasRequest
+	history.hasRequest = false
+	return tick, hasRequest
+}
+
+// EnableRollback makes the SubApp keep the state of its world after each of the last numberOfTicks runs of the
+// repeated schedules, so that it can be rolled back with [SubApp.Rollback] or [SubApp.RequestRollback]. The state
+// consists of the entities, components and resources of the world (see [ecs.SnapshotBinary]) and the pending events.
+//
+// Must be called before the app is run.
+func (app *SubApp) EnableRollback(numberOfTicks uint) *SubApp {
+	if numberOfTicks == 0 {
+		app.logger.Error("%s - failed to enable rollback: number of ticks can not be 0", app.Name)
+		return app
+	}
+
+	app.rollback = newRollbackHistory(numberOfTicks)
+	return app
+}
+
+// RequestRollback rolls the app back to the state after tick, once the current run of the repeated schedules is
+// done. The ticks after tick are then run again right away, without waiting for the runner, so that the app ends
+// up at the same tick as before the rollback. This can be called from systems. If multiple rollbacks get requested
+// during the same run, the app is rolled back to the earliest tick.
+func (app *SubApp) RequestRollback(tick uint) {
+	if app.rollback == nil {
+		app.logger.Error("%s - failed to request rollback: %v", app.Name, ErrRollbackNotEnabled)
+		return
+	}
+
+	app.rollback.request(tick)
+}
+
+// Rollback restores the state of the app after tick. The next run of the repeated schedules will be tick + 1. The
+// world and events are restored with [ecs.RestoreBinary] and [ecs.EventStorage.RestoreSnapshot].
+//
+// Must not be called while the systems of the app are running. Use [SubApp.RequestRollback] from systems instead.
+//
+// Can return the following errors:
+//   - ErrRollbackNotEnabled error if [SubApp.EnableRollback] did not get called.
+//   - ErrRollbackTickNotFound error if the state of tick is not (or no longer) kept.
+//   - Any error that [ecs.RestoreBinary] returns.
+func (app *SubApp) Rollback(tick uint) error {
+	if app.rollback == nil {
+		return ErrRollbackNotEnabled
+	}
+
+	snapshot, exists := app.rollback.get(tick)
+	if !exists || !snapshot.hasState {
+		return fmt.Errorf("%w: %d", ErrRollbackTickNotFound, tick)
+	}
+
+	app.world.Mutex.Lock()
+	defer app.world.Mutex.Unlock()
+
+	if err := ecs.RestoreBinary(app.world, snapshot.world); err != nil {
+		return fmt.Errorf("failed to restore world: %w", err)
+	}
+	app.world.Events().RestoreSnapshot(snapshot.events)
+
+	app.currentTick = tick + 1
+	*app.lastDelta = snapshot.delta
+	app.rollback.invalidateAfter(tick)
+
+	return nil
+}
+
+// Resimulate runs the repeated schedules right away until the current tick is untilTick, without waiting for the
+// runner. Ticks that ran before are run with the same delta time as the first time. The state after each tick is
+// kept if rollback is enabled.
+//
+// Must not be called while the systems of the app are running.
+func (app *SubApp) Resimulate(untilTick uint) {
+	runner := resimulationRunner{
+		RunnerBasis: NewRunnerBasis(app),
+		untilTick:   untilTick,
+		rollback:    app.rollback,
+	}
+	runner.isFirstRun = false
+	runner.setOnRunDone(app.onRepeatedRunDone)
+
+	runner.Run(nil, app.repeatedExecutor)
+}
+
+// onRepeatedRunDone is called after each run of the repeated schedules.
+func (app *SubApp) onRepeatedRunDone() {
+	if app.rollback != nil {
+		if err := app.rollback.capture(app.world, app.currentTick, *app.lastDelta); err != nil {
+			app.logger.Error("%s - failed to keep state of tick %d for rollback: %v", app.Name, app.currentTick, err)
+		}
+	}
+
+	app.currentTick++
+}
+
+// processRollbackRequest rolls back and resimulates the app if that got requested with [SubApp.RequestRollback].
+func (app *SubApp) processRollbackRequest() {
+	if app.rollback == nil {
+		return
+	}
+
+	tick, hasRequest := app.rollback.takeRequest()
+	if !hasRequest {
+		return
+	}
+
+	untilTick := app.currentTick
+	if err := app.Rollback(tick); err != nil {
+		app.logger.Error("%s - failed to roll back to tick %d: %v", app.Name, tick, err)
+		return
+	}
+
+	app.Resimulate(untilTick)
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/lucdrenth/murphecs/src/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	const update ecs.Schedule = "Update"
+
+	type counter struct{ Value int }
+	type marker struct{ ecs.Component }
+	type counted struct{ ecs.Event }
+
+	run := func(app *SubApp) {
+		isDoneChannel := make(chan bool)
+		go app.Run(make(chan struct{}), isDoneChannel)
+		<-isDoneChannel
+	}
+
+	newApp := func(t *testing.T, numberOfRuns int) (*SubApp, *TestLogger) {
+		logger := TestLogger{}
+		app, err := New(&logger, ecs.DefaultWorldConfigs())
+		assert.NoError(t, err)
+
+		app.AddSchedule(update, ScheduleOptions{ScheduleType: ScheduleTypeRepeating})
+		app.AddResource(&counter{})
+		app.AddSystem(update, func(world *ecs.World, counter *counter) {
+			counter.Value++
+			_, err := ecs.Spawn(world, &marker{})
+			assert.NoError(t, err)
+		})
+		app.UseNTimesRunner(numberOfRuns)
+
+		return app, &logger
+	}
+
+	t.Run("restores the state after a tick and resimulates the ticks after it", func(t *testing.T) {
+		assert := assert.New(t)
+
+		app, logger := newApp(t, 5)
+		app.EnableRollback(10)
+		run(app)
+		assert.Equal(uint(5), *app.GetCurrentTick())
+
+		assert.NoError(app.Rollback(2))
+		assert.Equal(uint(3), *app.GetCurrentTick())
+		assert.Equal(3, app.World().CountEntities())
+		value, err := ecs.GetResource[counter](app.World())
+		assert.NoError(err)
+		assert.Equal(3, value.Value)
+
+		app.Resimulate(5)
+		assert.Equal(uint(5), *app.GetCurrentTick())
+		assert.Equal(5, app.World().CountEntities())
+		value, err = ecs.GetResource[counter](app.World())
+		assert.NoError(err)
+		assert.Equal(5, value.Value)
+		assert.Equal(uint(0), logger.NumberOfErrorLogs)
+	})
+
+	t.Run("rolls back and resimulates when requested by a system", func(t *testing.T) {
+		assert := assert.New(t)
+
+		app, logger := newApp(t, 5)
+		app.EnableRollback(10)
+
+		observed := []int{}
+		hasRequested := false
+		app.AddSystem(update, func(counter counter) {
+			observed = append(observed, counter.Value)
+			if counter.Value == 4 && !hasRequested {
+				hasRequested = true
+				app.RequestRollback(1)
+			}
+		})
+
+		run(app)
+
+		// tick 3 requests to roll back to tick 1, after which tick 2 and 3 are run again
+		assert.Equal([]int{1, 2, 3, 4, 3, 4, 5}, observed)
+		assert.Equal(uint(5), *app.GetCurrentTick())
+		assert.Equal(5, app.World().CountEntities())
+		assert.Equal(uint(0), logger.NumberOfErrorLogs)
+	})
+
+	t.Run("restores pending events", func(t *testing.T) {
+		assert := assert.New(t)
+
+		app, _ := newApp(t, 2)
+		app.EnableRollback(10)
+
+		numberOfEvents := 0
+		app.AddSystem(update, func(writer *ecs.EventWriter[*counted], reader *ecs.EventReader[*counted]) {
+			numberOfEvents = reader.Len()
+			writer.Write(&counted{})
+		})
+		run(app)
+		assert.Equal(1, numberOfEvents)
+
+		assert.NoError(app.Rollback(0))
+		app.Resimulate(1)
+		assert.Equal(1, numberOfEvents)
+	})
+
+	t.Run("only keeps the state of the last ticks", func(t *testing.T) {
+		assert := assert.New(t)
+
+		app, _ := newApp(t, 5)
+		app.EnableRollback(2)
+		run(app)
+
+		assert.ErrorIs(app.Rollback(2), ErrRollbackTickNotFound)
+		assert.NoError(app.Rollback(3))
+		assert.ErrorIs(app.Rollback(4), ErrRollbackTickNotFound, "the state after tick 4 is no longer valid after rolling back to tick 3")
+	})
+
+	t.Run("returns an error when rollback is not enabled", func(t *testing.T) {
+		assert := assert.New(t)
+
+		app, logger := newApp(t, 1)
+		run(app)
+
+		assert.ErrorIs(app.Rollback(0), ErrRollbackNotEnabled)
+		app.RequestRollback(0)
+		assert.Equal(uint(1), logger.NumberOfErrorLogs)
+	})
+
+	t.Run("logs an error when enabling rollback for 0 ticks", func(t *testing.T) {
+		assert := assert.New(t)
+
+		app, logger := newApp(t, 1)
+		app.EnableRollback(0)
+		assert.Equal(uint(1), logger.NumberOfErrorLogs)
+	})
+}
@@ -140,3 +140,30 @@ func (runner *onceRunner) Run(exitChannel <-chan struct{}, executor Executor) {
 	executor.Run(*runner.currentTick)
 	runner.Done()
 }
+
+// resimulationRunner runs systems until the current tick is untilTick, without waiting in between runs. It is used
+// to run ticks again after a rollback, see [SubApp.Resimulate].
+type resimulationRunner struct {
+	RunnerBasis
+	untilTick uint
+	rollback  *rollbackHistory // used to run ticks with the same delta time as the first time, nil if not enabled
+}
+
+func (runner *resimulationRunner) Run(exitChannel <-chan struct{}, executor Executor) {
+	for *runner.currentTick < runner.untilTick {
+		select {
+		case <-exitChannel:
+			return
+		default:
+		}
+
+		if runner.rollback != nil {
+			if delta, exists := runner.rollback.delta(*runner.currentTick); exists {
+				*runner.delta = delta
+			}
+		}
+
+		executor.Run(*runner.currentTick)
+		runner.Done()
+	}
+}
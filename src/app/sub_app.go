@@ -27,7 +27,8 @@ type SubApp struct {
 	currentTick   uint
 	lastDelta     *float64 // delta time of the last tick
 	runner        Runner
-	features      []IFeature // this slice will be processed and emptied when starting this SubApp
+	features      []IFeature       // this slice will be processed and emptied when starting this SubApp
+	rollback      *rollbackHistory // nil if rollback is not enabled, see [SubApp.EnableRollback]
 
 	OnStartupSchedulesDone func()
 
@@ -224,7 +225,8 @@ func (app *SubApp) RunStartupSchedules(exitChannel <-chan struct{}) {
 		app.startupExecutor.ProcessEvents(app.currentTick)
 	})
 	app.runner.setOnRunDone(func() {
-		app.currentTick++
+		app.onRepeatedRunDone()
+		app.processRollbackRequest()
 	})
 }
 
@@ -308,6 +310,24 @@ func (app *SubApp) SetRunner(runner Runner) {
 	app.runner = runner
 }
 
+// UseParallelExecutor makes systems that do not conflict with each other run in parallel. See [ParallelExecutor].
+//
+// Must be called before the app is run.
+func (app *SubApp) UseParallelExecutor() {
+	app.startupExecutor = &ParallelExecutor{}
+	app.repeatedExecutor = &ParallelExecutor{}
+	app.cleanupExecutor = &ParallelExecutor{}
+}
+
+// UseConsecutiveExecutor makes systems run one after another. This is the default.
+//
+// Must be called before the app is run.
+func (app *SubApp) UseConsecutiveExecutor() {
+	app.startupExecutor = &ConsecutiveExecutor{}
+	app.repeatedExecutor = &ConsecutiveExecutor{}
+	app.cleanupExecutor = &ConsecutiveExecutor{}
+}
+
 // UseFixedRunner makes the systems run repeatedly, at a fixed interval. To control the interval time, use `app.SetTickRate`.
 func (app *SubApp) UseFixedRunner() {
 	app.runner = &fixedRunner{
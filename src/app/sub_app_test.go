@@ -2,6 +2,7 @@ package app
 
 import (
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -317,6 +318,35 @@ func TestRun(t *testing.T) {
 		assert.Equal(3, numberOfSystemRuns)
 	})
 
+	t.Run("runs all systems with the parallel executor", func(t *testing.T) {
+		assert := assert.New(t)
+
+		const update ecs.Schedule = "Update"
+
+		numberOfSystemRuns := atomic.Int32{}
+
+		logger := TestLogger{}
+		app, err := New(&logger, ecs.DefaultWorldConfigs())
+		assert.NoError(err)
+		app.UseParallelExecutor()
+
+		app.AddSchedule(update, ScheduleOptions{ScheduleType: ScheduleTypeRepeating})
+		app.
+			AddSystem(update, func() { numberOfSystemRuns.Add(1) }).
+			AddSystem(update, func() { numberOfSystemRuns.Add(1) }).
+			AddSystem(update, func(_ *ecs.World) { numberOfSystemRuns.Add(1) })
+
+		runner := app.newNTimesRunner(2)
+		app.SetRunner(&runner)
+
+		isDoneChannel := make(chan bool)
+		go app.Run(make(chan struct{}), isDoneChannel)
+		<-isDoneChannel
+
+		assert.Equal(uint(0), logger.NumberOfErrorLogs)
+		assert.Equal(int32(6), numberOfSystemRuns.Load())
+	})
+
 	t.Run("fixed runner stops when closing exit channel", func(t *testing.T) {
 		assert := assert.New(t)
 
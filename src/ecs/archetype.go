@@ -2,6 +2,8 @@ package ecs
 
 import (
 	"fmt"
+	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -10,21 +12,26 @@ import (
 
 type archetypeStorage struct {
 	componentsHashToArchetype map[string]*Archetype // this map stores a list of unique Archetype
-	entityIdToArchetype       map[EntityId]*Archetype
 	componentIdToArchetypes   map[ComponentId]*[]*Archetype
+	relationIdsByTarget       map[EntityId][]ComponentId // the relation pairs of each target entity, see [Relation]
+	archetypes                []*Archetype               // all archetypes in the order that they got created
 	idCounter                 uint
+
+	// The number of times that archetypes got removed, which invalidates the archetypes that queries cached.
+	// See [archetypeStorage.removeEmptyArchetypesWith].
+	removals uint
 }
 
 func newArchetypeStorage() archetypeStorage {
 	return archetypeStorage{
 		componentsHashToArchetype: map[string]*Archetype{},
-		entityIdToArchetype:       map[EntityId]*Archetype{},
 		componentIdToArchetypes:   map[ComponentId]*[]*Archetype{},
+		relationIdsByTarget:       map[EntityId][]ComponentId{},
 	}
 }
 
 // getArchetype either returns an existing archetype or creates a new one if it doesn't exist yet.
-func (s archetypeStorage) getArchetype(world *World, componentIds []ComponentId) (*Archetype, error) {
+func (s *archetypeStorage) getArchetype(world *World, componentIds []ComponentId) (*Archetype, error) {
 	sortComponentIds(componentIds)
 	hash := hashComponentIds(componentIds)
 	existingArchetype, exists := s.componentsHashToArchetype[hash]
@@ -38,6 +45,7 @@ func (s archetypeStorage) getArchetype(world *World, componentIds []ComponentId)
 	}
 
 	s.componentsHashToArchetype[hash] = newArchetype
+	s.archetypes = append(s.archetypes, newArchetype)
 
 	for i := range componentIds {
 		archetypeList, exists := s.componentIdToArchetypes[componentIds[i]]
@@ -45,12 +53,174 @@ func (s archetypeStorage) getArchetype(world *World, componentIds []ComponentId)
 			*archetypeList = append(*archetypeList, newArchetype)
 		} else {
 			s.componentIdToArchetypes[componentIds[i]] = &[]*Archetype{newArchetype}
+
+			if target, isRelation := componentIds[i].Target(); isRelation {
+				s.relationIdsByTarget[target] = append(s.relationIdsByTarget[target], componentIds[i])
+			}
+		}
+	}
+
+	return newArchetype, nil
+}
+
+// getArchetypeAfterInsert returns the archetype that an entity of archetype moves to when componentIdsToAdd are
+// inserted. newComponentIds must contain the components of archetype, componentIdsToAdd and their required components.
+//
+// When inserting a single component, the result is cached as an edge of archetype so that subsequent moves
+// don't have to sort and hash newComponentIds.
+func (s *archetypeStorage) getArchetypeAfterInsert(world *World, archetype *Archetype, componentIdsToAdd []ComponentId, newComponentIds []ComponentId) (*Archetype, error) {
+	if len(componentIdsToAdd) != 1 {
+		return s.getArchetype(world, newComponentIds)
+	}
+
+	componentId := componentIdsToAdd[0]
+	edge := archetype.getEdge(componentId)
+	if edge.add != nil {
+		return edge.add, nil
+	}
+
+	newArchetype, err := s.getArchetype(world, newComponentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.add = newArchetype
+
+	// Only link back if no required components were added, because removing componentId does not remove
+	// its required components.
+	if len(newArchetype.componentIds) == len(archetype.componentIds)+1 {
+		newArchetype.getEdge(componentId).remove = archetype
+	}
+
+	return newArchetype, nil
+}
+
+// getArchetypeAfterRemove returns the archetype that an entity of archetype moves to when componentIdsToRemove
+// are removed. All of componentIdsToRemove must be present in archetype.
+//
+// When removing a single component, the result is cached as an edge of archetype so that subsequent moves
+// don't have to sort and hash the new component ids.
+func (s *archetypeStorage) getArchetypeAfterRemove(world *World, archetype *Archetype, componentIdsToRemove []ComponentId) (*Archetype, error) {
+	var edge *archetypeEdge
+	if len(componentIdsToRemove) == 1 {
+		edge = archetype.getEdge(componentIdsToRemove[0])
+		if edge.remove != nil {
+			return edge.remove, nil
+		}
+	}
+
+	newComponentIds := make([]ComponentId, 0, len(archetype.componentIds))
+	for _, componentId := range archetype.componentIds {
+		if !slices.Contains(componentIdsToRemove, componentId) {
+			newComponentIds = append(newComponentIds, componentId)
+		}
+	}
+
+	newArchetype, err := s.getArchetype(world, newComponentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if edge != nil {
+		edge.remove = newArchetype
+
+		// Only link back if newArchetype already has all required components of the removed component, because
+		// adding it back would otherwise also add its required components.
+		if hasRequiredComponentsOf(world, newArchetype, componentIdsToRemove[0]) {
+			newArchetype.getEdge(componentIdsToRemove[0]).add = archetype
 		}
 	}
 
 	return newArchetype, nil
 }
 
+// hasRequiredComponentsOf returns whether archetype has all required components of componentId.
+func hasRequiredComponentsOf(world *World, archetype *Archetype, componentId ComponentId) bool {
+	component, isComponent := reflect.New(componentId.componentType).Interface().(AnyComponent)
+	if !isComponent {
+		return false
+	}
+
+	componentsToExclude := slices.Concat(archetype.componentIds, []ComponentId{componentId})
+	return len(getAllRequiredComponents(&componentsToExclude, []AnyComponent{component}, world)) == 0
+}
+
+// removeEmptyArchetypesWith removes the archetypes that contain componentId and that have no entities, and removes
+// componentId from the storage. This should only be called for components that can never be added again, such as
+// relation pairs of which the target got despawned, because it invalidates the archetypes that queries cached.
+func (s *archetypeStorage) removeEmptyArchetypesWith(componentId ComponentId) {
+	archetypes, exists := s.componentIdToArchetypes[componentId]
+	if !exists {
+		return
+	}
+
+	removed := []*Archetype{}
+	for _, archetype := range *archetypes {
+		if len(archetype.entities) == 0 {
+			removed = append(removed, archetype)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	isRemoved := func(archetype *Archetype) bool {
+		return slices.Contains(removed, archetype)
+	}
+
+	for _, archetype := range removed {
+		delete(s.componentsHashToArchetype, archetype.componentTypesHash)
+
+		for _, id := range archetype.componentIds {
+			archetypeList, exists := s.componentIdToArchetypes[id]
+			if !exists {
+				continue
+			}
+
+			*archetypeList = slices.DeleteFunc(*archetypeList, isRemoved)
+			if len(*archetypeList) == 0 {
+				delete(s.componentIdToArchetypes, id)
+				s.removeRelationIdOfTarget(id)
+			}
+		}
+	}
+
+	s.archetypes = slices.DeleteFunc(s.archetypes, isRemoved)
+	for _, archetype := range s.archetypes {
+		for _, edge := range archetype.edges {
+			if isRemoved(edge.add) {
+				edge.add = nil
+			}
+			if isRemoved(edge.remove) {
+				edge.remove = nil
+			}
+		}
+	}
+
+	s.removals++
+}
+
+// removeRelationIdOfTarget removes relationId from relationIdsByTarget, which must be done when it no longer has any
+// archetypes because getArchetype adds it again when it gets a new archetype.
+func (s *archetypeStorage) removeRelationIdOfTarget(relationId ComponentId) {
+	target, isRelation := relationId.Target()
+	if !isRelation {
+		return
+	}
+
+	relationIds, exists := s.relationIdsByTarget[target]
+	if !exists {
+		return
+	}
+
+	relationIds = slices.DeleteFunc(relationIds, func(id ComponentId) bool { return id == relationId })
+	if len(relationIds) == 0 {
+		delete(s.relationIdsByTarget, target)
+	} else {
+		s.relationIdsByTarget[target] = relationIds
+	}
+}
+
 // countComponents returns the number of living components
 func (storage *archetypeStorage) countComponents() uint {
 	count := uint(0)
@@ -67,7 +237,15 @@ type Archetype struct {
 	componentTypesHash string
 	components         map[ComponentId]*componentStorage
 	componentIds       []ComponentId
-	entities           []EntityId
+	entities           []EntityId                     // entities[row] is the entity of the components at row in the component storages
+	edges              map[ComponentId]*archetypeEdge // lazily initialized, see [Archetype.getEdge]
+}
+
+// archetypeEdge links an archetype to the archetypes that entities move to when a single component is
+// added to or removed from them. This forms a graph of archetypes in which repeated moves are a single lookup.
+type archetypeEdge struct {
+	add    *Archetype // nil if not yet resolved
+	remove *Archetype // nil if not yet resolved
 }
 
 // newArchetype returns a new archetype for the given componentIds.
@@ -115,20 +293,56 @@ func (archetype *Archetype) CountComponents() uint {
 	return count
 }
 
-func (archetype *Archetype) removeEntity(entity EntityId) error {
-	for i, entityId := range archetype.entities {
-		if entityId == entity {
-			utils.RemoveFromSlice(&archetype.entities, i)
-			return nil
-		}
+// getEdge returns the edge of componentId, creating it if it doesn't exist yet.
+func (archetype *Archetype) getEdge(componentId ComponentId) *archetypeEdge {
+	if archetype.edges == nil {
+		archetype.edges = map[ComponentId]*archetypeEdge{}
+	}
+
+	edge, exists := archetype.edges[componentId]
+	if !exists {
+		edge = &archetypeEdge{}
+		archetype.edges[componentId] = edge
 	}
 
-	return ErrEntityNotFound
+	return edge
+}
+
+// addEntity adds entity to the archetype and returns the row at which its components should be stored.
+func (archetype *Archetype) addEntity(entity EntityId) (row uint) {
+	row = uint(len(archetype.entities))
+	archetype.entities = append(archetype.entities, entity)
+	return row
+}
+
+// removeEntity removes the entity at row. The last entity of the archetype is moved to row, which mirrors
+// what [componentStorage.remove] does with the components. Returns the entity that got moved, if any.
+func (archetype *Archetype) removeEntity(row uint) (movedEntity EntityId, isMoved bool, err error) {
+	if row >= uint(len(archetype.entities)) {
+		return movedEntity, false, fmt.Errorf("%w: row %d", ErrEntityNotFound, row)
+	}
+
+	lastRow := uint(len(archetype.entities)) - 1
+	utils.RemoveFromSlice(&archetype.entities, int(row))
+
+	if row == lastRow {
+		return movedEntity, false, nil
+	}
+
+	return archetype.entities[row], true, nil
 }
 
 func sortComponentIds(componentIds []ComponentId) {
 	sort.Slice(componentIds, func(i, j int) bool {
-		return componentIds[i].id > componentIds[j].id
+		if componentIds[i].id != componentIds[j].id {
+			return componentIds[i].id > componentIds[j].id
+		}
+
+		// relation pairs of the same relation type
+		if componentIds[i].target.index != componentIds[j].target.index {
+			return componentIds[i].target.index > componentIds[j].target.index
+		}
+		return componentIds[i].target.generation > componentIds[j].target.generation
 	})
 }
 
@@ -151,11 +365,26 @@ func hashComponentIds(componentIds []ComponentId) string {
 
 	buf := make([]byte, 0, len(componentIds)*(maxDigitsPerComponentId+delimiterSize))
 
-	buf = strconv.AppendUint(buf, uint64(componentIds[0].id), 10)
+	buf = appendComponentIdHash(buf, &componentIds[0])
 	for i := 1; i < len(componentIds); i++ {
 		buf = append(buf, ',')
-		buf = strconv.AppendUint(buf, uint64(componentIds[i].id), 10)
+		buf = appendComponentIdHash(buf, &componentIds[i])
 	}
 
 	return string(buf)
 }
+
+// appendComponentIdHash appends the hash of a single component id to buf. Relation pairs are suffixed with their
+// target, such as "5@3:0", so that pairs with different targets get different hashes.
+func appendComponentIdHash(buf []byte, componentId *ComponentId) []byte {
+	buf = strconv.AppendUint(buf, uint64(componentId.id), 10)
+
+	if target, isRelation := componentId.Target(); isRelation {
+		buf = append(buf, '@')
+		buf = strconv.AppendUint(buf, uint64(target.index), 10)
+		buf = append(buf, ':')
+		buf = strconv.AppendUint(buf, uint64(target.generation), 10)
+	}
+
+	return buf
+}
@@ -169,3 +169,149 @@ func TestArchetypeIsFromComponents(t *testing.T) {
 		})
 	}
 }
+
+func TestArchetypeEdges(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+	type componentC struct{ Component }
+
+	t.Run("inserting a single component caches the add and remove edges", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
+		oldArchetype := entityData.archetype
+
+		err = Insert(world, entity, &componentB{})
+		assert.NoError(err)
+		entityData, err = world.entities.get(entity)
+		assert.NoError(err)
+		newArchetype := entityData.archetype
+
+		componentIdB := ComponentIdFor[componentB](world)
+		assert.Equal(newArchetype, oldArchetype.edges[componentIdB].add)
+		assert.Equal(oldArchetype, newArchetype.edges[componentIdB].remove)
+	})
+
+	t.Run("archetype moves use cached edges", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity1, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entity2, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		err = Insert(world, entity1, &componentB{})
+		assert.NoError(err)
+		err = Insert(world, entity2, &componentB{})
+		assert.NoError(err)
+
+		entityData1, err := world.entities.get(entity1)
+		assert.NoError(err)
+		entityData2, err := world.entities.get(entity2)
+		assert.NoError(err)
+		assert.Equal(entityData1.archetype, entityData2.archetype)
+
+		err = Remove1[componentB](world, entity1)
+		assert.NoError(err)
+		err = Remove1[componentB](world, entity2)
+		assert.NoError(err)
+		entityData1, err = world.entities.get(entity1)
+		assert.NoError(err)
+		entityData2, err = world.entities.get(entity2)
+		assert.NoError(err)
+		assert.Equal(entityData1.archetype, entityData2.archetype)
+		assert.True(entityData1.archetype.IsFromComponents([]ComponentId{ComponentIdFor[componentA](world)}))
+
+		assert.Len(world.archetypeStorage.componentsHashToArchetype, 2)
+	})
+
+	t.Run("removing a single component caches the remove and add edges", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{}, &componentB{}, &componentC{})
+		assert.NoError(err)
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
+		oldArchetype := entityData.archetype
+
+		err = Remove1[componentC](world, entity)
+		assert.NoError(err)
+		entityData, err = world.entities.get(entity)
+		assert.NoError(err)
+		newArchetype := entityData.archetype
+
+		componentIdC := ComponentIdFor[componentC](world)
+		assert.Equal(newArchetype, oldArchetype.edges[componentIdC].remove)
+		assert.Equal(oldArchetype, newArchetype.edges[componentIdC].add)
+	})
+
+	t.Run("does not link back when required components were added", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
+		oldArchetype := entityData.archetype
+
+		err = Insert(world, entity, &componentTree1B{})
+		assert.NoError(err)
+		entityData, err = world.entities.get(entity)
+		assert.NoError(err)
+		newArchetype := entityData.archetype
+
+		componentId := ComponentIdFor[componentTree1B](world)
+		assert.Equal(newArchetype, oldArchetype.edges[componentId].add)
+		assert.Nil(newArchetype.edges[componentId])
+
+		err = Remove1[componentTree1B](world, entity)
+		assert.NoError(err)
+		entityData, err = world.entities.get(entity)
+		assert.NoError(err)
+		assert.True(entityData.archetype.HasComponent(ComponentIdFor[componentTree2C](world)))
+	})
+
+	t.Run("does not link back when removing a component of which a required component got removed", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &testInsertComponentB{})
+		assert.NoError(err)
+		assert.NoError(Remove1[testInsertComponentA](world, entity))
+		assert.NoError(Remove1[testInsertComponentD](world, entity))
+		assert.NoError(Remove1[testInsertComponentB](world, entity))
+
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
+		assert.Nil(entityData.archetype.getEdge(ComponentIdFor[testInsertComponentB](world)).add)
+
+		other, err := Spawn(world)
+		assert.NoError(err)
+		assert.NoError(Insert(world, other, &testInsertComponentB{}))
+		hasA, err := HasComponent[testInsertComponentA](world, other)
+		assert.NoError(err)
+		assert.True(hasA)
+	})
+
+	t.Run("does not cache edges when moving multiple components", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
+		oldArchetype := entityData.archetype
+
+		err = Insert(world, entity, &componentB{}, &componentC{})
+		assert.NoError(err)
+		assert.Empty(oldArchetype.edges)
+	})
+}
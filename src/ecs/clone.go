@@ -0,0 +1,109 @@
+package ecs
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Clone spawns a new entity with a copy of all the components of entity. Components are copied shallowly, so
+// reference types such as slices and maps are shared between the entities.
+//
+// The clone gets the same [Parent] and relations (see [Relation]) as entity, but not its [Children].
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error when the given entity does not exist
+//   - Returns an ErrEntityStale error when the given entity has been despawned
+//   - Returns an ErrWorldIsLocked error while querying
+func Clone(world *World, entity EntityId) (EntityId, error) {
+	return cloneInto(world, world, entity)
+}
+
+// CloneInto spawns a new entity in dst with a copy of all the components of entity, which lives in src. The
+// component ids are remapped to the component ids of dst. Components are copied shallowly, so reference types
+// such as slices and maps are shared between the entities.
+//
+// Components that point to other entities of src are not copied, because those entities do not exist in dst.
+// These are [Parent], [Children] and relations (see [Relation]).
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error when the given entity does not exist in src
+//   - Returns an ErrEntityStale error when the given entity has been despawned
+//   - Returns an ErrWorldIsLocked error while querying dst
+func CloneInto(src *World, dst *World, entity EntityId) (EntityId, error) {
+	return cloneInto(src, dst, entity)
+}
+
+func cloneInto(src *World, dst *World, entity EntityId) (EntityId, error) {
+	if dst.isQuerying() {
+		// If we allow this, the clone may or may not be included in the query results, which is unpredictable.
+		return nonExistingEntity, ErrWorldIsLocked
+	}
+
+	entityData, err := src.entities.get(entity)
+	if err != nil {
+		return nonExistingEntity, err
+	}
+
+	isSameWorld := src == dst
+	parentId := ComponentIdFor[Parent](src)
+	childrenId := ComponentIdFor[Children](src)
+
+	srcArchetype := entityData.archetype
+	srcRow := entityData.row
+	srcComponentIds := make([]ComponentId, 0, len(srcArchetype.componentIds))
+	dstComponentIds := make([]ComponentId, 0, len(srcArchetype.componentIds))
+	for _, componentId := range srcArchetype.componentIds {
+		if componentId == childrenId {
+			continue
+		}
+
+		if !isSameWorld {
+			if _, isRelation := componentId.Target(); isRelation || componentId == parentId {
+				continue
+			}
+
+			srcComponentIds = append(srcComponentIds, componentId)
+			dstComponentIds = append(dstComponentIds, ComponentId{
+				id:            dst.componentRegistry.getId(componentId.componentType),
+				componentType: componentId.componentType,
+			})
+			continue
+		}
+
+		srcComponentIds = append(srcComponentIds, componentId)
+		dstComponentIds = append(dstComponentIds, componentId)
+	}
+
+	// getArchetype sorts the component ids that it is given, which would break the mapping to srcComponentIds.
+	dstArchetype, err := dst.archetypeStorage.getArchetype(dst, slices.Clone(dstComponentIds))
+	if err != nil {
+		return nonExistingEntity, err
+	}
+
+	for i, srcComponentId := range srcComponentIds {
+		rawComponent, err := srcArchetype.components[srcComponentId].getComponentPointer(srcRow)
+		if err != nil {
+			return nonExistingEntity, err
+		}
+
+		_, err = dstArchetype.components[dstComponentIds[i]].insertRaw(dst, rawComponent, newComponentTicks(dst.ChangeTick()))
+		if err != nil {
+			return nonExistingEntity, fmt.Errorf("failed to insert component %s in to component registry: %w", dstComponentIds[i].DebugString(), err)
+		}
+	}
+
+	clone, cloneData := dst.entities.create()
+	cloneData.archetype = dstArchetype
+	cloneData.row = dstArchetype.addEntity(clone)
+
+	var returnedErr error
+	if isSameWorld {
+		if parent, hasParent := getParent(dst, clone); hasParent {
+			returnedErr = addChild(dst, parent, clone)
+		}
+	}
+
+	dst.observers.triggerSpawnObservers(dst, dstComponentIds, clone)
+
+	return clone, returnedErr
+}
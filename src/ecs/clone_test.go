@@ -0,0 +1,182 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct {
+		Component
+		values []int
+	}
+	type componentC struct{ Component }
+	type targets struct{ Relation }
+
+	t.Run("clones all components of the entity", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &componentA{value: 1}, &componentB{values: []int{1, 2}})
+		assert.NoError(err)
+
+		clone, err := Clone(world, entity)
+		assert.NoError(err)
+		assert.NotEqual(entity, clone)
+		assert.Equal(2, world.CountEntities())
+
+		a, b, err := Get2[*componentA, componentB](world, clone)
+		assert.NoError(err)
+		assert.Equal(1, a.value)
+		assert.Equal([]int{1, 2}, b.values)
+
+		a.value = 2
+		original, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(1, original.value)
+	})
+
+	t.Run("the clone gets the parent and the relations of the entity, but not its children", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &componentC{})
+		assert.NoError(err)
+		entity, err := Spawn(world, &componentA{}, &Parent{Entity: parent})
+		assert.NoError(err)
+		_, err = Spawn(world, &Parent{Entity: entity})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, entity, parent, targets{}))
+
+		clone, err := Clone(world, entity)
+		assert.NoError(err)
+
+		children, err := Get1[Children](world, parent)
+		assert.NoError(err)
+		assert.Equal([]EntityId{entity, clone}, children.Entities())
+
+		hasChildren, err := HasComponent[Children](world, clone)
+		assert.NoError(err)
+		assert.False(hasChildren)
+
+		hasRelation, err := HasRelation[targets](world, clone, parent)
+		assert.NoError(err)
+		assert.True(hasRelation)
+	})
+
+	t.Run("triggers spawn observers", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		observed := []EntityId{}
+		assert.NoError(On[OnSpawn[componentA]](world, func(event OnSpawn[componentA]) {
+			observed = append(observed, event.Entity)
+		}))
+
+		clone, err := Clone(world, entity)
+		assert.NoError(err)
+		assert.Equal([]EntityId{clone}, observed)
+	})
+
+	t.Run("returns an error if the entity does not exist", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Clone(world, nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+	})
+
+	t.Run("returns an error while querying", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		world.startQuerying()
+		defer world.stopQuerying()
+		_, err = Clone(world, entity)
+		assert.ErrorIs(err, ErrWorldIsLocked)
+	})
+}
+
+func TestCloneInto(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct {
+		Component
+		value string
+	}
+	type componentC struct{ Component }
+	type targets struct{ Relation }
+
+	t.Run("clones the components in to another world with remapped component ids", func(t *testing.T) {
+		assert := assert.New(t)
+		src := NewDefaultWorld()
+		dst := NewDefaultWorld()
+
+		// register the components in a different order so that their ids differ between the worlds
+		_, err := Spawn(dst, &componentC{}, &componentB{})
+		assert.NoError(err)
+		assert.NotEqual(ComponentIdFor[componentA](src).id, ComponentIdFor[componentA](dst).id)
+
+		entity, err := Spawn(src, &componentA{value: 1}, &componentB{value: "b"})
+		assert.NoError(err)
+
+		clone, err := CloneInto(src, dst, entity)
+		assert.NoError(err)
+		assert.Equal(1, src.CountEntities())
+		assert.Equal(2, dst.CountEntities())
+
+		a, b, err := Get2[componentA, componentB](dst, clone)
+		assert.NoError(err)
+		assert.Equal(1, a.value)
+		assert.Equal("b", b.value)
+
+		query := Query2[componentA, componentB, Default]{}
+		assert.NoError(query.Prepare(dst, nil))
+		assert.NoError(query.Exec(dst))
+		assert.Equal(uint(1), query.NumberOfResult())
+	})
+
+	t.Run("does not clone components that point to entities of the source world", func(t *testing.T) {
+		assert := assert.New(t)
+		src := NewDefaultWorld()
+		dst := NewDefaultWorld()
+
+		parent, err := Spawn(src, &componentC{})
+		assert.NoError(err)
+		entity, err := Spawn(src, &componentA{}, &Parent{Entity: parent})
+		assert.NoError(err)
+		_, err = Spawn(src, &Parent{Entity: entity})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(src, entity, parent, targets{}))
+
+		clone, err := CloneInto(src, dst, entity)
+		assert.NoError(err)
+
+		entityData, err := dst.entities.get(clone)
+		assert.NoError(err)
+		assert.Equal([]ComponentId{ComponentIdFor[componentA](dst)}, entityData.archetype.componentIds)
+	})
+
+	t.Run("returns an error if the entity does not exist in the source world", func(t *testing.T) {
+		assert := assert.New(t)
+		src := NewDefaultWorld()
+		dst := NewDefaultWorld()
+
+		_, err := CloneInto(src, dst, nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+		assert.Equal(0, dst.CountEntities())
+	})
+}
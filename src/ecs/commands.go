@@ -0,0 +1,193 @@
+package ecs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// command is a deferred operation on a world.
+type command func(world *World) error
+
+// Commands records operations on a [World] so that they can be applied later. This makes it possible to
+// spawn, despawn, insert and remove components while iterating a query, where doing so directly would
+// return an ErrWorldIsLocked error.
+//
+// Commands can be used as a system param, in which case the recorded commands are applied at the end
+// of the schedule that the system is in, in the order in which the systems got added. Errors of commands
+// that are used as a system param are logged by the logger of the world.
+//
+// Commands is safe to use from multiple goroutines, for example from within [Query1.ParIter].
+type Commands struct {
+	world    *World
+	commands []command
+	mutex    sync.Mutex
+}
+
+// NewCommands returns Commands that can be applied to world.
+func NewCommands(world *World) *Commands {
+	return &Commands{world: world}
+}
+
+func (c *Commands) push(cmd command) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.commands = append(c.commands, cmd)
+}
+
+// Len returns the number of commands that have not been applied yet.
+func (c *Commands) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.commands)
+}
+
+// Spawn records spawning an entity with the given components. See [Spawn].
+//
+// The returned EntityId is reserved right away, so that it can be used by commands that are recorded later.
+// The entity does not exist in the world until the commands are applied.
+func (c *Commands) Spawn(components ...AnyComponent) EntityId {
+	entity := c.world.entities.reserve()
+
+	c.push(func(world *World) error {
+		err := spawnReserved(world, entity, components...)
+		if err != nil {
+			// The entity will never be spawned, so its id can be reused.
+			_ = world.entities.release(entity)
+			return fmt.Errorf("failed to spawn entity %s: %w", entity, err)
+		}
+		return nil
+	})
+
+	return entity
+}
+
+// Insert records inserting components into an entity. See [Insert].
+func (c *Commands) Insert(entity EntityId, components ...AnyComponent) {
+	c.push(func(world *World) error {
+		err := Insert(world, entity, components...)
+		if err != nil {
+			return fmt.Errorf("failed to insert components into entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// InsertOrOverwrite records inserting or overwriting components of an entity. See [InsertOrOverwrite].
+func (c *Commands) InsertOrOverwrite(entity EntityId, components ...AnyComponent) {
+	c.push(func(world *World) error {
+		err := InsertOrOverwrite(world, entity, components...)
+		if err != nil {
+			return fmt.Errorf("failed to insert or overwrite components of entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// Despawn records despawning an entity. See [Despawn].
+func (c *Commands) Despawn(entity EntityId) {
+	c.push(func(world *World) error {
+		err := Despawn(world, entity)
+		if err != nil {
+			return fmt.Errorf("failed to despawn entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// DespawnRecursive records despawning an entity and all of its descendants. See [DespawnRecursive].
+func (c *Commands) DespawnRecursive(entity EntityId) {
+	c.push(func(world *World) error {
+		err := DespawnRecursive(world, entity)
+		if err != nil {
+			return fmt.Errorf("failed to despawn entity %s recursively: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// AddResource records adding a resource. See [resourceStorage.Add].
+func (c *Commands) AddResource(resource Resource) {
+	c.push(func(world *World) error {
+		err := world.Resources().Add(resource)
+		if err != nil {
+			return fmt.Errorf("failed to add resource %s: %w", GetResourceDebugType(resource), err)
+		}
+		return nil
+	})
+}
+
+// Run records a custom operation on the world.
+func (c *Commands) Run(f func(world *World) error) {
+	c.push(f)
+}
+
+// Apply applies all recorded commands to the world, in the order that they got recorded, and clears them.
+// A command that returns an error does not stop the other commands from being applied.
+//
+// Can return the following errors:
+//   - ErrWorldIsLocked error while querying
+//   - any error that is returned by the individual commands
+func (c *Commands) Apply() []error {
+	if c.world.isQuerying() {
+		return []error{ErrWorldIsLocked}
+	}
+
+	c.mutex.Lock()
+	commands := c.commands
+	c.commands = nil
+	c.mutex.Unlock()
+
+	errors := []error{}
+	for _, cmd := range commands {
+		err := cmd(c.world)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	return errors
+}
+
+// DeferRemove1 records removing component A from an entity. See [Remove1].
+func DeferRemove1[A AnyComponent](commands *Commands, entity EntityId) {
+	commands.push(func(world *World) error {
+		err := Remove1[A](world, entity)
+		if err != nil {
+			return fmt.Errorf("failed to remove components from entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// DeferRemove2 records removing components A and B from an entity. See [Remove2].
+func DeferRemove2[A, B AnyComponent](commands *Commands, entity EntityId) {
+	commands.push(func(world *World) error {
+		err := Remove2[A, B](world, entity)
+		if err != nil {
+			return fmt.Errorf("failed to remove components from entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// DeferRemove3 records removing components A, B and C from an entity. See [Remove3].
+func DeferRemove3[A, B, C AnyComponent](commands *Commands, entity EntityId) {
+	commands.push(func(world *World) error {
+		err := Remove3[A, B, C](world, entity)
+		if err != nil {
+			return fmt.Errorf("failed to remove components from entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
+
+// DeferRemove4 records removing components A, B, C and D from an entity. See [Remove4].
+func DeferRemove4[A, B, C, D AnyComponent](commands *Commands, entity EntityId) {
+	commands.push(func(world *World) error {
+		err := Remove4[A, B, C, D](world, entity)
+		if err != nil {
+			return fmt.Errorf("failed to remove components from entity %s: %w", entity, err)
+		}
+		return nil
+	})
+}
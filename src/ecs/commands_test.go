@@ -0,0 +1,167 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingLogger struct {
+	NoOpLogger
+	numberOfErrorLogs int
+}
+
+func (l *countingLogger) Error(message string, arguments ...any) {
+	l.numberOfErrorLogs++
+}
+
+func TestCommands(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct{ Component }
+	type resourceA struct{}
+
+	t.Run("applies commands in the order they got recorded", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		commands := NewCommands(world)
+
+		entity := commands.Spawn(&componentA{value: 1})
+		commands.Insert(entity, &componentB{})
+		commands.InsertOrOverwrite(entity, &componentA{value: 2})
+		commands.AddResource(&resourceA{})
+		assert.False(EntityExists(world, entity))
+		assert.Equal(4, commands.Len())
+
+		errs := commands.Apply()
+		assert.Empty(errs)
+		assert.Equal(0, commands.Len())
+
+		a, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(2, a.value)
+		hasB, err := HasComponent[componentB](world, entity)
+		assert.NoError(err)
+		assert.True(hasB)
+		_, err = GetResource[*resourceA](world)
+		assert.NoError(err)
+	})
+
+	t.Run("removes components and despawns entities", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entityA, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		entityB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		commands := NewCommands(world)
+		DeferRemove1[componentB](commands, entityA)
+		commands.Despawn(entityB)
+		errs := commands.Apply()
+		assert.Empty(errs)
+
+		hasB, err := HasComponent[componentB](world, entityA)
+		assert.NoError(err)
+		assert.False(hasB)
+		assert.False(EntityExists(world, entityB))
+	})
+
+	t.Run("can be recorded while querying", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		for range 3 {
+			_, err := Spawn(world, &componentA{})
+			assert.NoError(err)
+		}
+
+		query := Query0[With[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+
+		commands := NewCommands(world)
+		query.Iter(func(entityId EntityId) {
+			commands.Despawn(entityId)
+			assert.ErrorIs(commands.Apply()[0], ErrWorldIsLocked)
+		})
+
+		errs := commands.Apply()
+		assert.Empty(errs)
+		assert.Equal(0, world.CountEntities())
+	})
+
+	t.Run("continues applying after a command failed", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		commands := NewCommands(world)
+
+		commands.Despawn(nonExistingEntity)
+		entity := commands.Spawn(&componentA{})
+		errs := commands.Apply()
+		assert.Len(errs, 1)
+		assert.ErrorIs(errs[0], ErrEntityNotFound)
+		assert.True(EntityExists(world, entity))
+	})
+
+	t.Run("releases the reserved entity if spawning fails", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		commands := NewCommands(world)
+
+		entity := commands.Spawn(&componentA{}, &componentA{})
+		errs := commands.Apply()
+		assert.Len(errs, 1)
+		assert.ErrorIs(errs[0], ErrComponentDuplicate)
+		assert.False(world.entities.isReserved(entity))
+
+		newEntity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.Equal(entity.Index(), newEntity.Index())
+		assert.False(EntityExists(world, entity))
+	})
+
+	t.Run("is applied at the end of the schedule when used as system param", func(t *testing.T) {
+		assert := assert.New(t)
+
+		logger := countingLogger{}
+		configs := DefaultWorldConfigs()
+		configs.Logger = &logger
+		world, err := NewWorld(configs)
+		assert.NoError(err)
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		var spawned EntityId
+		err = scheduleSystems.add(Systems(
+			func(commands *Commands) {
+				spawned = commands.Spawn(&componentA{})
+			},
+			func(commands *Commands) {
+				// the entity is not spawned until the end of the schedule
+				assert.False(EntityExists(&world, spawned))
+				commands.Insert(spawned, &componentB{})
+				commands.Despawn(nonExistingEntity)
+			},
+		), "", &world, nil, &logger, &eventStorage)
+		assert.NoError(err)
+
+		errs := scheduleSystems.Exec(&world, nil, &eventStorage, 0)
+		assert.Empty(errs)
+		hasB, err := HasComponent[componentB](&world, spawned)
+		assert.NoError(err)
+		assert.True(hasB)
+		assert.Equal(1, logger.numberOfErrorLogs)
+	})
+
+	t.Run("returns an error when used as non-pointer system param", func(t *testing.T) {
+		assert := assert.New(t)
+		// created with reflection because go vet does not allow passing Commands by value
+		systemType := reflect.FuncOf([]reflect.Type{reflect.TypeFor[Commands]()}, nil, false)
+		system := reflect.MakeFunc(systemType, func([]reflect.Value) []reflect.Value { return nil }).Interface()
+		err := simpleTestAddSystem(system)
+		assert.ErrorIs(err, ErrSystemParamCommandsNotAPointer)
+	})
+}
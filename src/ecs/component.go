@@ -83,15 +83,24 @@ func (Component) RequiredComponents() []AnyComponent {
 type ComponentId struct {
 	id            uint
 	componentType reflect.Type
+	target        EntityId // the target of a relation pair, see [Relation]. nonExistingEntity for other components
 }
 
 func (c *ComponentId) DebugString() string {
 	result, _ := strings.CutPrefix(c.componentType.String(), "*")
+	if c.target != nonExistingEntity {
+		result += "(" + c.target.String() + ")"
+	}
 	return result
 }
 
 func (c *ComponentId) Is(other *ComponentId) bool {
-	return other.id == c.id
+	return other.id == c.id && other.target == c.target
+}
+
+// Target returns the target entity if the component id is of a relation pair. See [Relation].
+func (c *ComponentId) Target() (EntityId, bool) {
+	return c.target, c.target != nonExistingEntity
 }
 
 func (c *ComponentId) Id() uint {
@@ -3,6 +3,7 @@ package ecs
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"unsafe"
 
 	"github.com/lucdrenth/murphecs/src/utils"
@@ -17,6 +18,19 @@ type componentStorage struct {
 	nextItemIndex      uint // the next inserted component will be inserted at this index
 	capacity           uint // the number of components that can be stored with the current size of data
 	numberOfComponents uint // the number of components that this storage contains
+
+	ticks []componentTicks // ticks[i] belongs to the component at index i
+}
+
+// componentTicks are the change ticks (see [World.ChangeTick]) at which a component got added to its entity,
+// and at which it got changed last.
+type componentTicks struct {
+	added   uint32
+	changed uint32
+}
+
+func newComponentTicks(tick uint32) componentTicks {
+	return componentTicks{added: tick, changed: tick}
 }
 
 // createComponentStorage creates a new instance of createComponentStorage that can hold [capacity] components of type [ComponentId].
@@ -48,6 +62,35 @@ func (storage *componentStorage) increaseCapacity(extraCapacity uint) {
 	storage.capacity = newCapacity
 }
 
+// reserve grows the storage, if needed, so that count more components can be inserted without growing in between.
+func (storage *componentStorage) reserve(count uint) {
+	requiredCapacity := storage.nextItemIndex + count
+	if requiredCapacity > storage.capacity {
+		storage.increaseCapacity(requiredCapacity - storage.capacity)
+	}
+
+	storage.ticks = slices.Grow(storage.ticks, int(count))
+}
+
+// clear removes all components from the storage. The capacity of the storage is kept.
+func (storage *componentStorage) clear() {
+	storage.data.Slice(0, int(storage.nextItemIndex)).Clear()
+	storage.ticks = storage.ticks[:0]
+	storage.nextItemIndex = 0
+	storage.numberOfComponents = 0
+}
+
+// replace replaces the components of the storage with the first count components of data, which must be an
+// addressable array of the component type, and ticks, which must contain the ticks of those components.
+func (storage *componentStorage) replace(data reflect.Value, count uint, ticks []componentTicks) {
+	storage.data = data
+	storage.pointerToStart = data.Addr().UnsafePointer()
+	storage.capacity = uint(data.Len())
+	storage.ticks = ticks
+	storage.nextItemIndex = count
+	storage.numberOfComponents = count
+}
+
 // insert returns the index at which the component was inserted.
 func (storage *componentStorage) insert(world *World, component AnyComponent) (uint, error) {
 	insertIndex := storage.nextItemIndex
@@ -65,6 +108,7 @@ func (storage *componentStorage) insert(world *World, component AnyComponent) (u
 		return 0, err
 	}
 
+	storage.ticks = append(storage.ticks, newComponentTicks(world.ChangeTick()))
 	storage.nextItemIndex += 1
 	storage.numberOfComponents += 1
 
@@ -86,8 +130,9 @@ func (storage *componentStorage) set(component AnyComponent, index uint) error {
 	return nil
 }
 
-// insertRaw returns the index at which the component was inserted.
-func (storage *componentStorage) insertRaw(world *World, componentPointer unsafe.Pointer) (uint, error) {
+// insertRaw returns the index at which the component was inserted. It is used to move a component to another
+// storage, so the ticks of the component are kept.
+func (storage *componentStorage) insertRaw(world *World, componentPointer unsafe.Pointer, ticks componentTicks) (uint, error) {
 	insertIndex := storage.nextItemIndex
 
 	if storage.capacity == insertIndex {
@@ -101,6 +146,7 @@ func (storage *componentStorage) insertRaw(world *World, componentPointer unsafe
 	src := reflect.NewAt(storage.componentId.componentType, componentPointer).Elem()
 	storage.data.Index(int(insertIndex)).Set(src)
 
+	storage.ticks = append(storage.ticks, ticks)
 	storage.nextItemIndex += 1
 	storage.numberOfComponents += 1
 
@@ -124,6 +170,7 @@ func (storage *componentStorage) insertValue(world *World, component *reflect.Va
 		return 0, err
 	}
 
+	storage.ticks = append(storage.ticks, newComponentTicks(world.ChangeTick()))
 	storage.nextItemIndex += 1
 	storage.numberOfComponents += 1
 
@@ -158,27 +205,31 @@ func (storage *componentStorage) remove(index uint) (*movedComponent, error) {
 		return nil, fmt.Errorf("%w: %d", ErrComponentStorageIndexOutOfBounds, index)
 	}
 
-	storage.numberOfComponents -= 1
+	lastIndex := storage.nextItemIndex - 1
+	var result *movedComponent
 
-	if storage.nextItemIndex == 0 || index >= (storage.nextItemIndex-1) {
-		return nil, nil
-	}
-
-	// move the last component in the storage to the index of the removed component to reuse the memory block.
+	if index != lastIndex {
+		// move the last component in the storage to the index of the removed component to reuse the memory block.
+		result = &movedComponent{
+			fromIndex: lastIndex,
+			toIndex:   index,
+		}
 
-	result := movedComponent{
-		fromIndex: storage.nextItemIndex - 1,
-		toIndex:   index,
+		err := storage.copyComponent(result.fromIndex, result.toIndex)
+		if err != nil {
+			return result, fmt.Errorf("failed to move component: %w", err)
+		}
+		storage.ticks[index] = storage.ticks[lastIndex]
 	}
+	storage.ticks = storage.ticks[:lastIndex]
 
-	err := storage.copyComponent(result.fromIndex, result.toIndex)
-	if err != nil {
-		return &result, fmt.Errorf("failed to move component: %w", err)
-	}
+	// Zero the freed up spot so that the garbage collector can clean up anything the component pointed to.
+	storage.data.Index(int(lastIndex)).SetZero()
 
 	storage.nextItemIndex -= 1
+	storage.numberOfComponents -= 1
 
-	return &result, nil
+	return result, nil
 }
 
 // copyComponent copies a component from one index in the storage to another. Both indices must already be
@@ -201,6 +252,11 @@ func (storage *componentStorage) copyComponent(fromIndex uint, toIndex uint) err
 	return nil
 }
 
+// markChanged sets the changed tick of the component at index.
+func (storage *componentStorage) markChanged(index uint, tick uint32) {
+	storage.ticks[index].changed = tick
+}
+
 // getComponentPointer returns an unsafe.Pointer to the component at index.
 //
 // Returns an error if index is out of bounds.
@@ -222,6 +278,11 @@ func getComponentFromComponentStorage[T AnyComponent](storage *componentStorage,
 		return result, err
 	}
 
+	return componentFromPointer[T](storage, componentPointer, isPointer), nil
+}
+
+// componentFromPointer converts componentPointer, which must point to a component in storage, to T.
+func componentFromPointer[T AnyComponent](storage *componentStorage, componentPointer unsafe.Pointer, isPointer bool) (result T) {
 	// Check if the generic type T is a pointer (e.g., *componentA)
 	if isPointer {
 		// Use reflect.NewAt to safely create a pointer of type T pointing to componentPointer.
@@ -231,11 +292,9 @@ func getComponentFromComponentStorage[T AnyComponent](storage *componentStorage,
 		//   `*(*unsafe.Pointer)(unsafe.Pointer(&result)) = componentPointer`
 		//
 		//
-		result = reflect.NewAt(storage.componentId.componentType, componentPointer).Interface().(T)
-	} else {
-		// Make 'result' a copy of the data at that address.
-		result = *(*T)(componentPointer)
+		return reflect.NewAt(storage.componentId.componentType, componentPointer).Interface().(T)
 	}
 
-	return result, nil
+	// Make 'result' a copy of the data at that address.
+	return *(*T)(componentPointer)
 }
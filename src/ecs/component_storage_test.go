@@ -333,6 +333,8 @@ func TestRemoveFromComponentStorage(t *testing.T) {
 		movedComponent, err := componentStorage.remove(nrComponents - 1)
 		assert.NoError(err)
 		assert.Nil(movedComponent)
+		assert.Equal(nrComponents-1, componentStorage.nextItemIndex)
+		assert.Equal(nrComponents-1, componentStorage.numberOfComponents)
 	})
 
 	t.Run("moves the last component to the place of the removed component", func(t *testing.T) {
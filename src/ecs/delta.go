@@ -0,0 +1,814 @@
+package ecs
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// WorldDelta describes the differences between two states of a world: the entities that got spawned or despawned,
+// and the components and relations that got added, changed or removed per entity. Create one with [DiffSnapshots] or
+// [DiffWorld] and apply it to a world with [ApplyDelta].
+//
+// [Children] are not part of a delta because they follow from the [Parent] components. Resources are not part of a
+// delta either.
+type WorldDelta struct {
+	Spawned   []EntityId
+	Despawned []EntityId
+
+	// Entities holds the component changes of each entity, including the components of spawned entities.
+	Entities []EntityDelta
+}
+
+// EntityDelta describes the component changes of a single entity, see [WorldDelta].
+type EntityDelta struct {
+	Entity  EntityId
+	Added   []ComponentDelta
+	Changed []ComponentDelta
+	Removed []ComponentDelta // the Value of removed components is nil
+}
+
+// ComponentDelta is a component or relation pair of an entity, see [WorldDelta].
+type ComponentDelta struct {
+	Type   string   // the type name of the component, see [reflect.Type.String]
+	Target EntityId // the target of a relation pair, or the zero value for other components
+
+	// Value is the component, encoded in the same way as the components of [SnapshotBinary].
+	Value []byte
+
+	// componentType, value and previous are only set if the delta got created in this process. value and previous
+	// are used to describe the change in [WorldDelta.String].
+	componentType reflect.Type
+	value         reflect.Value
+	previous      reflect.Value
+}
+
+// IsEmpty returns whether the delta does not contain any changes.
+func (delta *WorldDelta) IsEmpty() bool {
+	return len(delta.Spawned) == 0 && len(delta.Despawned) == 0 && len(delta.Entities) == 0
+}
+
+// String describes all changes of the delta, one change per line. This is useful to print the differences between
+// two worlds that are expected to be the same.
+func (delta WorldDelta) String() string {
+	lines := []string{}
+	for _, entity := range delta.Spawned {
+		lines = append(lines, "spawned "+entity.String())
+	}
+	for _, entity := range delta.Despawned {
+		lines = append(lines, "despawned "+entity.String())
+	}
+
+	for _, entityDelta := range delta.Entities {
+		for _, component := range entityDelta.Added {
+			line := fmt.Sprintf("%s added %s", entityDelta.Entity, component.typeString())
+			if component.value.IsValid() {
+				line += fmt.Sprintf(" %+v", component.value.Interface())
+			}
+			lines = append(lines, line)
+		}
+		for _, component := range entityDelta.Changed {
+			line := fmt.Sprintf("%s changed %s", entityDelta.Entity, component.typeString())
+			if component.value.IsValid() && component.previous.IsValid() {
+				line += fmt.Sprintf(" %+v -> %+v", component.previous.Interface(), component.value.Interface())
+			}
+			lines = append(lines, line)
+		}
+		for _, component := range entityDelta.Removed {
+			lines = append(lines, fmt.Sprintf("%s removed %s", entityDelta.Entity, component.typeString()))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// MarshalBinary encodes the delta, so that it can be sent to another process. Use [WorldDelta.UnmarshalBinary] to
+// decode it. Just like binary snapshots, the encoded delta can only be decoded by the same version of this package,
+// on a machine with the same memory layout.
+func (delta WorldDelta) MarshalBinary() ([]byte, error) {
+	encoder := binaryEncoder{}
+	encoder.header()
+
+	encoder.uvarint(uint64(len(delta.Spawned)))
+	for _, entity := range delta.Spawned {
+		encoder.entityId(entity)
+	}
+	encoder.uvarint(uint64(len(delta.Despawned)))
+	for _, entity := range delta.Despawned {
+		encoder.entityId(entity)
+	}
+
+	encoder.uvarint(uint64(len(delta.Entities)))
+	for _, entityDelta := range delta.Entities {
+		encoder.entityId(entityDelta.Entity)
+		for _, components := range [][]ComponentDelta{entityDelta.Added, entityDelta.Changed, entityDelta.Removed} {
+			encoder.uvarint(uint64(len(components)))
+			for _, component := range components {
+				encoder.string(component.Type)
+				encoder.entityId(component.Target)
+				encoder.uvarint(uint64(len(component.Value)))
+				encoder.bytes(component.Value)
+			}
+		}
+	}
+
+	return encoder.buffer, nil
+}
+
+// UnmarshalBinary decodes a delta that got encoded by [WorldDelta.MarshalBinary].
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotNotValid error when data is not a valid encoded delta
+func (delta *WorldDelta) UnmarshalBinary(data []byte) error {
+	decoder := binaryDecoder{data: data}
+	if err := decoder.header(); err != nil {
+		return err
+	}
+
+	entityIds := func() []EntityId {
+		result := make([]EntityId, decoder.length(2))
+		for i := range result {
+			result[i] = decoder.entityId()
+		}
+		return result
+	}
+	components := func() []ComponentDelta {
+		result := make([]ComponentDelta, decoder.length(3))
+		for i := range result {
+			result[i] = ComponentDelta{
+				Type:   decoder.string(),
+				Target: decoder.entityId(),
+			}
+			if value := decoder.bytes(decoder.length(1)); len(value) > 0 {
+				result[i].Value = slices.Clone(value)
+			}
+		}
+		return result
+	}
+
+	result := WorldDelta{
+		Spawned:   entityIds(),
+		Despawned: entityIds(),
+		Entities:  make([]EntityDelta, decoder.length(4)),
+	}
+	for i := range result.Entities {
+		result.Entities[i] = EntityDelta{
+			Entity:  decoder.entityId(),
+			Added:   components(),
+			Changed: components(),
+			Removed: components(),
+		}
+	}
+
+	if decoder.err != nil {
+		return decoder.err
+	}
+	if decoder.offset != len(decoder.data) {
+		return fmt.Errorf("%w: unexpected data after the end of the delta", ErrSnapshotNotValid)
+	}
+
+	*delta = result
+	return nil
+}
+
+func (component *ComponentDelta) typeString() string {
+	if component.Target != nonExistingEntity {
+		return component.Type + "(" + component.Target.String() + ")"
+	}
+	return component.Type
+}
+
+// DiffSnapshots returns the changes that turn the world of snapshot from in to the world of snapshot to. Both
+// snapshots must be created by [SnapshotBinary]. The component types of the snapshots are looked up in world, in the
+// same way as [RestoreBinary] does, but world itself is not compared or changed.
+//
+// Components are compared with [reflect.DeepEqual], including their unexported fields.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotRegistered error when a component type is not known by world
+//   - Returns an ErrSnapshotNotValid error when from or to is not a valid binary snapshot
+//   - Returns an ErrSnapshotTypeNotSupported error when a changed component contains a non-nil interface, channel,
+//     function, unsafe.Pointer or a pointer cycle
+func DiffSnapshots(world *World, from, to []byte) (WorldDelta, error) {
+	fromState, err := snapshotDeltaState(world, from)
+	if err != nil {
+		return WorldDelta{}, fmt.Errorf("failed to decode snapshot from: %w", err)
+	}
+
+	toState, err := snapshotDeltaState(world, to)
+	if err != nil {
+		return WorldDelta{}, fmt.Errorf("failed to decode snapshot to: %w", err)
+	}
+
+	return diffDeltaStates(fromState, toState)
+}
+
+// DiffWorld returns the changes that turn the world of snapshot from in to the current state of world. from must be
+// created by [SnapshotBinary].
+//
+// Components are compared with [reflect.DeepEqual], including their unexported fields.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotRegistered error when a component type of from is not known by world
+//   - Returns an ErrSnapshotNotValid error when from is not a valid binary snapshot
+//   - Returns an ErrSnapshotTypeNotSupported error when a changed component contains a non-nil interface, channel,
+//     function, unsafe.Pointer or a pointer cycle
+func DiffWorld(world *World, from []byte) (WorldDelta, error) {
+	fromState, err := snapshotDeltaState(world, from)
+	if err != nil {
+		return WorldDelta{}, err
+	}
+
+	return diffDeltaStates(fromState, worldDeltaState(world, nil, nil))
+}
+
+// ApplyDelta applies the changes of delta to world. This can be another world than the one that the delta got
+// created from.
+//
+// entityMap maps the entity ids of delta to the entity ids of world, and must contain all entities of delta that are
+// not spawned by it. Spawned entities get new entity ids, which are added to entityMap. Despawned entities are
+// removed from entityMap. An empty map can be used for the first delta, which is the difference with an empty world.
+//
+// All [EntityId] values in the exported fields of components, and the targets of relations, are rewritten to the
+// entity ids of world in the same way as [RestoreJSON] does. Entity ids that are not in entityMap are rewritten to
+// the zero value of EntityId.
+//
+// The component types of delta must be known by world, see [RegisterComponent] and [GetComponentTypeByString].
+// world is left untouched if delta can not be decoded.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotRegistered error when a component type is not known by world
+//   - Returns an ErrSnapshotNotValid error when a component value can not be decoded
+//   - Returns an ErrEntityNotFound error when an entity of delta is not in entityMap
+//   - Returns an ErrRelationTargetNotFound error when the target of a relation is not in entityMap
+//   - Returns an ErrWorldIsLocked error while querying
+//   - Any error that [Spawn], [InsertOrOverwrite] or [Despawn] returns.
+func ApplyDelta(world *World, delta WorldDelta, entityMap map[EntityId]EntityId) error {
+	if world.isQuerying() {
+		// If we allow this, the changed entities may or may not be included in the query results, which is unpredictable.
+		return ErrWorldIsLocked
+	}
+
+	// decode everything before changing anything, so that an invalid delta leaves world untouched
+	isSpawned := make(map[EntityId]bool, len(delta.Spawned))
+	for _, entity := range delta.Spawned {
+		isSpawned[entity] = true
+	}
+	for _, entity := range delta.Despawned {
+		if _, exists := entityMap[entity]; !exists && !isSpawned[entity] {
+			return fmt.Errorf("%w: %s", ErrEntityNotFound, entity)
+		}
+	}
+
+	type decodedComponent struct {
+		value  reflect.Value // a pointer to the component
+		target EntityId
+	}
+
+	types := map[string]reflect.Type{}
+	componentType := func(typeName string) (reflect.Type, error) {
+		result, exists := types[typeName]
+		if !exists {
+			result = snapshotComponentTypeByString(world, typeName)
+			if result == nil {
+				return nil, fmt.Errorf("%w: component %s", ErrSnapshotTypeNotRegistered, typeName)
+			}
+			types[typeName] = result
+		}
+		return result, nil
+	}
+
+	setComponents := make([][]decodedComponent, len(delta.Entities))
+	removedComponents := make([][]decodedComponent, len(delta.Entities))
+	for i, entityDelta := range delta.Entities {
+		if _, exists := entityMap[entityDelta.Entity]; !exists && !isSpawned[entityDelta.Entity] {
+			return fmt.Errorf("%w: %s", ErrEntityNotFound, entityDelta.Entity)
+		}
+
+		for _, component := range slices.Concat(entityDelta.Added, entityDelta.Changed) {
+			valueType, err := componentType(component.Type)
+			if err != nil {
+				return err
+			}
+
+			value := reflect.New(valueType)
+			decoder := binaryDecoder{data: component.Value}
+			decoder.value(value.Elem())
+			if decoder.err == nil && decoder.offset != len(decoder.data) {
+				decoder.fail("unexpected data after the end of the component")
+			}
+			if decoder.err != nil {
+				return fmt.Errorf("failed to decode component %s of entity %s: %w", component.typeString(), entityDelta.Entity, decoder.err)
+			}
+
+			setComponents[i] = append(setComponents[i], decodedComponent{value: value, target: component.Target})
+		}
+
+		for _, component := range entityDelta.Removed {
+			valueType, err := componentType(component.Type)
+			if err != nil {
+				return err
+			}
+
+			removedComponents[i] = append(removedComponents[i], decodedComponent{value: reflect.New(valueType), target: component.Target})
+		}
+	}
+
+	for _, entity := range delta.Spawned {
+		spawned, err := Spawn(world)
+		if err != nil {
+			return err
+		}
+		entityMap[entity] = spawned
+	}
+
+	componentId := func(component decodedComponent) (ComponentId, error) {
+		result := ComponentId{
+			id:            world.componentRegistry.getId(component.value.Type().Elem()),
+			componentType: component.value.Type().Elem(),
+		}
+
+		if component.target != nonExistingEntity {
+			target, exists := entityMap[component.target]
+			if !exists {
+				return ComponentId{}, fmt.Errorf("%w: %s", ErrRelationTargetNotFound, component.target)
+			}
+			result.target = target
+		}
+
+		return result, nil
+	}
+
+	for i, entityDelta := range delta.Entities {
+		entity := entityMap[entityDelta.Entity]
+
+		if len(removedComponents[i]) > 0 {
+			componentIds := make([]ComponentId, len(removedComponents[i]))
+			for j, component := range removedComponents[i] {
+				id, err := componentId(component)
+				if err != nil {
+					return fmt.Errorf("failed to remove relation of entity %s: %w", entityDelta.Entity, err)
+				}
+				componentIds[j] = id
+			}
+
+			if err := removeComponents(world, entity, componentIds); err != nil {
+				return fmt.Errorf("failed to remove components of entity %s: %w", entityDelta.Entity, err)
+			}
+		}
+
+		components := []AnyComponent{}
+		for _, component := range setComponents[i] {
+			remapEntityIds(component.value, entityMap)
+			if component.target == nonExistingEntity {
+				components = append(components, component.value.Interface().(AnyComponent))
+				continue
+			}
+
+			relationId, err := componentId(component)
+			if err == nil {
+				err = insertOrOverwriteRelation(world, entity, component.value.Interface().(AnyComponent), relationId)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to set relation %s of entity %s: %w", component.value.Type().Elem().String(), entityDelta.Entity, err)
+			}
+		}
+
+		if len(components) > 0 {
+			if err := InsertOrOverwrite(world, entity, components...); err != nil {
+				return fmt.Errorf("failed to set components of entity %s: %w", entityDelta.Entity, err)
+			}
+		}
+	}
+
+	for _, entity := range delta.Despawned {
+		if err := Despawn(world, entityMap[entity]); err != nil {
+			return fmt.Errorf("failed to despawn entity %s: %w", entity, err)
+		}
+		delete(entityMap, entity)
+	}
+
+	return nil
+}
+
+// insertOrOverwriteRelation inserts relation in to entity, or overwrites it if entity already has it.
+func insertOrOverwriteRelation(world *World, entity EntityId, relation AnyComponent, relationId ComponentId) error {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return err
+	}
+
+	if !entityData.archetype.HasComponent(relationId) {
+		return insert(world, entity, []AnyComponent{relation}, []ComponentId{relationId})
+	}
+
+	storage := entityData.archetype.components[relationId]
+	if err := storage.set(relation, entityData.row); err != nil {
+		return err
+	}
+	storage.markChanged(entityData.row, world.ChangeTick())
+
+	return nil
+}
+
+// DeltaTracker keeps a copy of the state of a world, so that it can return the changes of the world since the
+// previous call to [DeltaTracker.Next]. This can be used to send only the changes of a world to another world each
+// tick, instead of the full world.
+type DeltaTracker struct {
+	state     deltaState
+	filter    func(ComponentId) bool
+	relevance *Relevance // nil if all entities are relevant
+
+	// world is the world of the previous call to Next, nil if Next did not get called yet. Components of world that got
+	// added or changed after change tick lastRun are compared with state, other components are unchanged.
+	world   *World
+	lastRun uint32
+}
+
+// NewDeltaTracker returns a DeltaTracker that tracks the components for which filter returns true, such as
+// [IsReplicated]. Entities that do not have any of these components are not tracked. All components and entities are
+// tracked if filter is nil.
+func NewDeltaTracker(filter func(componentId ComponentId) bool) *DeltaTracker {
+	return &DeltaTracker{
+		state:  deltaState{},
+		filter: filter,
+	}
+}
+
+// SetRelevance makes the tracker only track the entities that are relevant according to relevance, starting with the
+// next call to [DeltaTracker.Next]. Entities that become relevant show up as spawned and entities that are no longer
+// relevant show up as despawned. Relations to entities that are not relevant are left out, so they show up as removed
+// when their target is no longer relevant. All entities are relevant if relevance is nil.
+func (tracker *DeltaTracker) SetRelevance(relevance *Relevance) {
+	tracker.relevance = relevance
+}
+
+// Next returns the changes of world since the previous call to Next. The first call returns the changes compared to
+// an empty world. Components are compared with [reflect.DeepEqual], including their unexported fields.
+//
+// Only the components that got added or changed since the previous call according to change detection (see
+// [Changed]) are compared, except for the entities that were not tracked yet, such as entities that became relevant.
+// Modifications that are not seen by change detection, such as those through a pointer that [Get1] returned, are not
+// detected. Use [GetMut] or a query with a pointer component instead. All components are compared if world is not the
+// world of the previous call.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotSupported error when a changed component contains a non-nil interface, channel,
+//     function, unsafe.Pointer or a pointer cycle. The changes are then returned again by the next call.
+func (tracker *DeltaTracker) Next(world *World) (WorldDelta, error) {
+	if tracker.relevance != nil {
+		tracker.relevance.prepare(world)
+	}
+
+	changeTicks := queryChangeTicks{lastRun: tracker.lastRun, thisRun: world.advanceChangeTick()}
+	// Advance once more so that changes that are made after this call are newer than thisRun.
+	world.advanceChangeTick()
+
+	var delta WorldDelta
+	var err error
+	if tracker.world == world {
+		delta, err = tracker.changedDelta(world, changeTicks)
+	} else {
+		delta, err = diffDeltaStates(tracker.state, worldDeltaState(world, tracker.filter, tracker.relevance))
+	}
+	if err != nil {
+		return WorldDelta{}, err
+	}
+
+	tracker.world = world
+	tracker.lastRun = changeTicks.thisRun
+	if world.currentScheduleSystemsId != 0 {
+		// Systems that run later in the same schedule mark components as changed at their own change tick, which is
+		// older than thisRun, so the next call has to check all changes since the schedule started.
+		tracker.lastRun = world.scheduleChangeTick
+	}
+
+	for _, entity := range delta.Despawned {
+		delete(tracker.state, entity)
+	}
+
+	for _, entityDelta := range delta.Entities {
+		components, exists := tracker.state[entityDelta.Entity]
+		if !exists {
+			components = make(map[deltaComponentKey]reflect.Value, len(entityDelta.Added))
+			tracker.state[entityDelta.Entity] = components
+		}
+
+		for _, component := range entityDelta.Removed {
+			delete(components, deltaComponentKey{componentType: component.componentType, target: component.Target})
+		}
+
+		// The tracked components are decoded from their encoded value, so that they do not share data behind pointers
+		// with the components of world.
+		for _, component := range slices.Concat(entityDelta.Added, entityDelta.Changed) {
+			value := reflect.New(component.componentType).Elem()
+			decoder := binaryDecoder{data: component.Value}
+			decoder.value(value)
+			components[deltaComponentKey{componentType: component.componentType, target: component.Target}] = value
+		}
+	}
+
+	return delta, nil
+}
+
+// deltaState holds the components of each entity of a world, to compare worlds with each other.
+type deltaState map[EntityId]map[deltaComponentKey]reflect.Value
+
+type deltaComponentKey struct {
+	componentType reflect.Type
+	target        EntityId
+}
+
+// snapshotDeltaState returns the components of each entity of a snapshot that got created by [SnapshotBinary].
+func snapshotDeltaState(world *World, data []byte) (deltaState, error) {
+	snapshot, err := decodeBinarySnapshot(world, data)
+	if err != nil {
+		return nil, err
+	}
+
+	childrenType := reflect.TypeFor[Children]()
+	state := make(deltaState, snapshot.numberOfEntities)
+	for _, archetype := range snapshot.archetypes {
+		for row, entity := range archetype.entities {
+			components := make(map[deltaComponentKey]reflect.Value, len(archetype.componentIds))
+			for i, componentId := range archetype.componentIds {
+				if componentId.componentType == childrenType {
+					continue
+				}
+
+				key := deltaComponentKey{componentType: componentId.componentType, target: componentId.target}
+				components[key] = archetype.storages[i].data.Index(row)
+			}
+			state[entity] = components
+		}
+	}
+
+	return state, nil
+}
+
+// worldDeltaState returns a copy of the components of each entity of world. If filter is not nil, only the
+// components for which filter returns true are included, and entities without such components are left out. If
+// relevance is not nil, only relevant entities are included and relevance must be prepared. Relation pairs of which
+// the target is left out are left out as well.
+func worldDeltaState(world *World, filter func(ComponentId) bool, relevance *Relevance) deltaState {
+	isIncluded := includedDeltaEntities(world, filter, relevance)
+
+	state := make(deltaState, world.CountEntities())
+	for _, archetype := range world.archetypeStorage.archetypes {
+		componentIds := deltaComponentIds(archetype, filter, isIncluded)
+
+		for row, entity := range archetype.entities {
+			if isIncluded != nil && !isIncluded[entity] {
+				continue
+			}
+
+			components := make(map[deltaComponentKey]reflect.Value, len(componentIds))
+			for _, componentId := range componentIds {
+
+				// the component is copied so that the delta does not change along with world
+				component := reflect.New(componentId.componentType).Elem()
+				component.Set(archetype.components[componentId].data.Index(row))
+
+				key := deltaComponentKey{componentType: componentId.componentType, target: componentId.target}
+				components[key] = component
+			}
+			state[entity] = components
+		}
+	}
+
+	return state
+}
+
+// includedDeltaEntities returns the entities of world that are part of a delta. If filter is not nil, only the
+// entities with a component for which filter returns true are included. If relevance is not nil, only relevant
+// entities are included and relevance must be prepared. Returns nil if all entities are included.
+func includedDeltaEntities(world *World, filter func(ComponentId) bool, relevance *Relevance) map[EntityId]bool {
+	if filter == nil && relevance == nil {
+		return nil
+	}
+
+	isIncluded := make(map[EntityId]bool, world.CountEntities())
+	for _, archetype := range world.archetypeStorage.archetypes {
+		if relevance != nil && !relevance.archetypeIsRelevant(archetype) {
+			continue
+		}
+		if filter != nil && len(deltaComponentIds(archetype, filter, nil)) == 0 {
+			continue
+		}
+
+		for row, entity := range archetype.entities {
+			if relevance == nil || relevance.rowIsRelevant(world, archetype, uint(row), entity) {
+				isIncluded[entity] = true
+			}
+		}
+	}
+
+	return isIncluded
+}
+
+// deltaComponentIds returns the components of archetype that are part of a delta. If filter is not nil, only the
+// components for which filter returns true are included. If isIncluded is not nil, relation pairs of which the target
+// is not included are left out, because the target would not exist when applying the delta.
+func deltaComponentIds(archetype *Archetype, filter func(ComponentId) bool, isIncluded map[EntityId]bool) []ComponentId {
+	childrenType := reflect.TypeFor[Children]()
+	componentIds := make([]ComponentId, 0, len(archetype.componentIds))
+	for _, componentId := range archetype.componentIds {
+		if componentId.componentType == childrenType || (filter != nil && !filter(componentId)) {
+			continue
+		}
+		if isIncluded != nil && componentId.target != nonExistingEntity && !isIncluded[componentId.target] {
+			continue
+		}
+
+		componentIds = append(componentIds, componentId)
+	}
+
+	return componentIds
+}
+
+// changedDelta returns the changes of world compared to the state of the tracker. Components of tracked entities are
+// only compared if they got added or changed according to changeTicks, so that unchanged components are not copied.
+// All components of entities that are not tracked yet are included. The relevance of the tracker must be prepared.
+func (tracker *DeltaTracker) changedDelta(world *World, changeTicks queryChangeTicks) (WorldDelta, error) {
+	delta := WorldDelta{}
+	isSeen := make(map[EntityId]bool, len(tracker.state))
+	isIncluded := includedDeltaEntities(world, tracker.filter, tracker.relevance)
+
+	for _, archetype := range world.archetypeStorage.archetypes {
+		componentIds := deltaComponentIds(archetype, tracker.filter, isIncluded)
+
+		for row, entity := range archetype.entities {
+			if isIncluded != nil && !isIncluded[entity] {
+				continue
+			}
+
+			isSeen[entity] = true
+			previousComponents, isTracked := tracker.state[entity]
+			if !isTracked {
+				delta.Spawned = append(delta.Spawned, entity)
+			}
+
+			entityDelta := EntityDelta{Entity: entity}
+			numberOfPreviousComponents := 0
+			for _, componentId := range componentIds {
+				key := deltaComponentKey{componentType: componentId.componentType, target: componentId.target}
+				storage := archetype.components[componentId]
+
+				previous, hadComponent := previousComponents[key]
+				if hadComponent {
+					numberOfPreviousComponents++
+					if !changeTicks.isNewer(storage.ticks[row].changed) {
+						continue
+					}
+				}
+
+				// the component is copied so that the delta does not change along with world
+				value := reflect.New(componentId.componentType).Elem()
+				value.Set(storage.data.Index(row))
+				if hadComponent && reflect.DeepEqual(previous.Interface(), value.Interface()) {
+					continue
+				}
+
+				component, err := newComponentDelta(key, value)
+				if err != nil {
+					return WorldDelta{}, fmt.Errorf("failed to encode component %s of entity %s: %w", component.typeString(), entity, err)
+				}
+
+				if hadComponent {
+					component.previous = previous
+					entityDelta.Changed = append(entityDelta.Changed, component)
+				} else {
+					entityDelta.Added = append(entityDelta.Added, component)
+				}
+			}
+
+			if numberOfPreviousComponents < len(previousComponents) {
+				isPresent := make(map[deltaComponentKey]bool, len(componentIds))
+				for _, componentId := range componentIds {
+					isPresent[deltaComponentKey{componentType: componentId.componentType, target: componentId.target}] = true
+				}
+
+				for key := range previousComponents {
+					if !isPresent[key] {
+						entityDelta.Removed = append(entityDelta.Removed, ComponentDelta{
+							Type:          key.componentType.String(),
+							Target:        key.target,
+							componentType: key.componentType,
+						})
+					}
+				}
+			}
+
+			if len(entityDelta.Added) > 0 || len(entityDelta.Changed) > 0 || len(entityDelta.Removed) > 0 {
+				delta.Entities = append(delta.Entities, entityDelta)
+			}
+		}
+	}
+
+	if len(isSeen) != len(tracker.state)+len(delta.Spawned) {
+		for entity := range tracker.state {
+			if !isSeen[entity] {
+				delta.Despawned = append(delta.Despawned, entity)
+			}
+		}
+	}
+
+	sortWorldDelta(&delta)
+	return delta, nil
+}
+
+// diffDeltaStates returns the changes that turn from in to to.
+func diffDeltaStates(from, to deltaState) (WorldDelta, error) {
+	delta := WorldDelta{}
+	for entity := range from {
+		if _, exists := to[entity]; !exists {
+			delta.Despawned = append(delta.Despawned, entity)
+		}
+	}
+
+	for entity, components := range to {
+		previousComponents, existed := from[entity]
+		if !existed {
+			delta.Spawned = append(delta.Spawned, entity)
+		}
+
+		entityDelta := EntityDelta{Entity: entity}
+		for key, value := range components {
+			previous, hadComponent := previousComponents[key]
+			if hadComponent && reflect.DeepEqual(previous.Interface(), value.Interface()) {
+				continue
+			}
+
+			component, err := newComponentDelta(key, value)
+			if err != nil {
+				return WorldDelta{}, fmt.Errorf("failed to encode component %s of entity %s: %w", component.typeString(), entity, err)
+			}
+
+			if hadComponent {
+				component.previous = previous
+				entityDelta.Changed = append(entityDelta.Changed, component)
+			} else {
+				entityDelta.Added = append(entityDelta.Added, component)
+			}
+		}
+
+		for key := range previousComponents {
+			if _, exists := components[key]; !exists {
+				entityDelta.Removed = append(entityDelta.Removed, ComponentDelta{
+					Type:          key.componentType.String(),
+					Target:        key.target,
+					componentType: key.componentType,
+				})
+			}
+		}
+
+		if len(entityDelta.Added) > 0 || len(entityDelta.Changed) > 0 || len(entityDelta.Removed) > 0 {
+			delta.Entities = append(delta.Entities, entityDelta)
+		}
+	}
+
+	sortWorldDelta(&delta)
+	return delta, nil
+}
+
+// sortWorldDelta sorts the entities and components of delta, so that equal deltas are always in the same order.
+func sortWorldDelta(delta *WorldDelta) {
+	for _, entityDelta := range delta.Entities {
+		slices.SortFunc(entityDelta.Added, compareComponentDeltas)
+		slices.SortFunc(entityDelta.Changed, compareComponentDeltas)
+		slices.SortFunc(entityDelta.Removed, compareComponentDeltas)
+	}
+
+	slices.SortFunc(delta.Spawned, compareEntityIds)
+	slices.SortFunc(delta.Despawned, compareEntityIds)
+	slices.SortFunc(delta.Entities, func(a, b EntityDelta) int {
+		return compareEntityIds(a.Entity, b.Entity)
+	})
+}
+
+func newComponentDelta(key deltaComponentKey, value reflect.Value) (ComponentDelta, error) {
+	component := ComponentDelta{
+		Type:          key.componentType.String(),
+		Target:        key.target,
+		componentType: key.componentType,
+		value:         value,
+	}
+
+	encoder := binaryEncoder{}
+	if err := encoder.value(value); err != nil {
+		return component, err
+	}
+	component.Value = encoder.buffer
+
+	return component, nil
+}
+
+func compareComponentDeltas(a, b ComponentDelta) int {
+	return cmp.Or(strings.Compare(a.Type, b.Type), compareEntityIds(a.Target, b.Target))
+}
+
+func compareEntityIds(a, b EntityId) int {
+	return cmp.Or(cmp.Compare(a.index, b.index), cmp.Compare(a.generation, b.generation))
+}
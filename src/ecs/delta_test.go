@@ -0,0 +1,483 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorldDelta(t *testing.T) {
+	type deltaPosition struct {
+		Component
+		X, Y int
+	}
+	type deltaFollower struct {
+		Component
+		Leader EntityId
+		path   []int
+	}
+	type deltaTag struct{ Component }
+	type deltaTargets struct {
+		Relation
+		Damage int
+	}
+
+	registerTypes := func(world *World) {
+		RegisterComponent[deltaPosition](world)
+		RegisterComponent[deltaFollower](world)
+		RegisterComponent[deltaTag](world)
+		RegisterComponent[deltaTargets](world)
+	}
+
+	t.Run("describes the differences between two snapshots", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		moved, err := Spawn(world, &deltaPosition{X: 1, Y: 2})
+		assert.NoError(err)
+		tagged, err := Spawn(world, &deltaTag{})
+		assert.NoError(err)
+		despawned, err := Spawn(world)
+		assert.NoError(err)
+		unchanged, err := Spawn(world, &deltaFollower{Leader: moved, path: []int{1, 2}})
+		assert.NoError(err)
+		from, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		position, err := Get1[*deltaPosition](world, moved)
+		assert.NoError(err)
+		position.X = 10
+		assert.NoError(Remove1[deltaTag](world, tagged))
+		assert.NoError(InsertRelation(world, tagged, unchanged, &deltaTargets{Damage: 3}))
+		assert.NoError(Despawn(world, despawned))
+		spawned, err := Spawn(world, &deltaTag{})
+		assert.NoError(err)
+		to, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		delta, err := DiffSnapshots(world, from, to)
+		assert.NoError(err)
+		assert.False(delta.IsEmpty())
+		assert.Equal([]EntityId{spawned}, delta.Spawned)
+		assert.Equal([]EntityId{despawned}, delta.Despawned)
+		assert.Len(delta.Entities, 3)
+
+		assert.Equal(moved, delta.Entities[0].Entity)
+		assert.Len(delta.Entities[0].Changed, 1)
+		assert.Equal(tagged, delta.Entities[1].Entity)
+		assert.Len(delta.Entities[1].Added, 1)
+		assert.Equal(unchanged, delta.Entities[1].Added[0].Target)
+		assert.Len(delta.Entities[1].Removed, 1)
+		assert.Equal(spawned, delta.Entities[2].Entity)
+		assert.Len(delta.Entities[2].Added, 1)
+
+		assert.Equal(
+			"spawned 3:1\n"+
+				"despawned 3:0\n"+
+				"1:0 changed ecs.deltaPosition {Component:{} X:1 Y:2} -> {Component:{} X:10 Y:2}\n"+
+				"2:0 added ecs.deltaTargets(4:0) {Relation:{Component:{}} Damage:3}\n"+
+				"2:0 removed ecs.deltaTag\n"+
+				"3:1 added ecs.deltaTag {Component:{}}",
+			delta.String(),
+		)
+	})
+
+	t.Run("is empty when the world did not change", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &deltaFollower{path: []int{1}})
+		assert.NoError(err)
+		_, err = Spawn(world, &Parent{Entity: parent}, &deltaPosition{})
+		assert.NoError(err)
+		snapshot, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		delta, err := DiffWorld(world, snapshot)
+		assert.NoError(err)
+		assert.True(delta.IsEmpty(), delta.String())
+
+		follower, err := Get1[*deltaFollower](world, parent)
+		assert.NoError(err)
+		follower.path[0] = 2
+
+		delta, err = DiffWorld(world, snapshot)
+		assert.NoError(err)
+		assert.Len(delta.Entities, 1)
+		assert.Len(delta.Entities[0].Changed, 1)
+	})
+
+	t.Run("replicates a world to another world", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		replica := NewDefaultWorld()
+		registerTypes(replica)
+		// occupy the first slots so that the replicated entities get different ids
+		_, err := SpawnBatch(replica, 3)
+		assert.NoError(err)
+		entityMap := map[EntityId]EntityId{}
+
+		previous, err := SnapshotBinary(NewDefaultWorld())
+		assert.NoError(err)
+		replicate := func() {
+			delta, err := DiffWorld(world, previous)
+			assert.NoError(err)
+			assert.NoError(ApplyDelta(replica, delta, entityMap))
+
+			previous, err = SnapshotBinary(world)
+			assert.NoError(err)
+		}
+
+		leader, err := Spawn(world, &deltaPosition{X: 1})
+		assert.NoError(err)
+		follower, err := Spawn(world, &deltaFollower{Leader: leader, path: []int{4}}, &Parent{Entity: leader})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, leader, follower, &deltaTargets{Damage: 1}))
+		replicate()
+
+		assert.Equal(5, replica.CountEntities())
+		assert.NotEqual(leader, entityMap[leader])
+		result, err := Get1[deltaFollower](replica, entityMap[follower])
+		assert.NoError(err)
+		assert.Equal(entityMap[leader], result.Leader)
+		assert.Equal([]int{4}, result.path)
+		children, err := Get1[Children](replica, entityMap[leader])
+		assert.NoError(err)
+		assert.Equal([]EntityId{entityMap[follower]}, children.Entities())
+		relation, err := GetRelation[deltaTargets](replica, entityMap[leader], entityMap[follower])
+		assert.NoError(err)
+		assert.Equal(1, relation.Damage)
+
+		position, err := Get1[*deltaPosition](world, leader)
+		assert.NoError(err)
+		position.X = 2
+		assert.NoError(RemoveRelation[deltaTargets](world, leader, follower))
+		assert.NoError(InsertRelation(world, follower, leader, &deltaTargets{Damage: 2}))
+		assert.NoError(Insert(world, follower, &deltaTag{}))
+		replicate()
+
+		replicatedPosition, err := Get1[deltaPosition](replica, entityMap[leader])
+		assert.NoError(err)
+		assert.Equal(2, replicatedPosition.X)
+		hasRelation, err := HasRelation[deltaTargets](replica, entityMap[leader], entityMap[follower])
+		assert.NoError(err)
+		assert.False(hasRelation)
+		relation, err = GetRelation[deltaTargets](replica, entityMap[follower], entityMap[leader])
+		assert.NoError(err)
+		assert.Equal(2, relation.Damage)
+		hasTag, err := HasComponent[deltaTag](replica, entityMap[follower])
+		assert.NoError(err)
+		assert.True(hasTag)
+
+		replicatedFollower := entityMap[follower]
+		assert.NoError(Despawn(world, follower))
+		replicate()
+
+		assert.Equal(4, replica.CountEntities())
+		assert.NotContains(entityMap, follower)
+		_, err = Get1[deltaFollower](replica, replicatedFollower)
+		assert.ErrorIs(err, ErrEntityNotFound)
+		hasChildren, err := HasComponent[Children](replica, entityMap[leader])
+		assert.NoError(err)
+		assert.False(hasChildren)
+	})
+
+	t.Run("returns an error when an entity is not in the entity map", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		registerTypes(world)
+
+		entity, err := Spawn(world)
+		assert.NoError(err)
+		from, err := SnapshotBinary(world)
+		assert.NoError(err)
+		assert.NoError(Insert(world, entity, &deltaTag{}))
+
+		delta, err := DiffWorld(world, from)
+		assert.NoError(err)
+
+		replica := NewDefaultWorld()
+		registerTypes(replica)
+		assert.ErrorIs(ApplyDelta(replica, delta, map[EntityId]EntityId{}), ErrEntityNotFound)
+		assert.Equal(0, replica.CountEntities())
+	})
+
+	t.Run("returns an error and leaves the world untouched when a type is not registered", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		empty, err := SnapshotBinary(world)
+		assert.NoError(err)
+		_, err = Spawn(world, &deltaPosition{})
+		assert.NoError(err)
+
+		delta, err := DiffWorld(world, empty)
+		assert.NoError(err)
+
+		replica := NewDefaultWorld()
+		assert.ErrorIs(ApplyDelta(replica, delta, map[EntityId]EntityId{}), ErrSnapshotTypeNotRegistered)
+		assert.Equal(0, replica.CountEntities())
+	})
+
+	t.Run("returns an error when a snapshot is not valid", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := DiffWorld(world, []byte("not a snapshot"))
+		assert.ErrorIs(err, ErrSnapshotNotValid)
+	})
+}
+
+func TestDeltaTracker(t *testing.T) {
+	type trackedPosition struct {
+		Component
+		Replicated
+		X    int
+		Path []int
+	}
+	type trackedSecret struct {
+		Component
+		Value int
+	}
+
+	t.Run("returns the changes since the previous call", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		tracker := NewDeltaTracker(nil)
+
+		entity, err := Spawn(world, &trackedPosition{X: 1, Path: []int{1}})
+		assert.NoError(err)
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{entity}, delta.Spawned)
+
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.True(delta.IsEmpty(), delta.String())
+
+		// changes behind pointers are detected, because the tracker keeps its own copy
+		position, err := GetMut[trackedPosition](world, entity)
+		assert.NoError(err)
+		position.Ptr().Path[0] = 2
+
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Len(delta.Entities, 1)
+		assert.Len(delta.Entities[0].Changed, 1)
+
+		// components that are marked as changed but are equal to the tracked copy are not included
+		position, err = GetMut[trackedPosition](world, entity)
+		assert.NoError(err)
+		position.Set(trackedPosition{X: 1, Path: []int{2}})
+
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.True(delta.IsEmpty(), delta.String())
+
+		assert.NoError(Despawn(world, entity))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{entity}, delta.Despawned)
+	})
+
+	t.Run("only tracks the components that match the filter", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		tracker := NewDeltaTracker(IsReplicated)
+
+		replicated, err := Spawn(world, &trackedPosition{X: 1}, &trackedSecret{Value: 1})
+		assert.NoError(err)
+		_, err = Spawn(world, &trackedSecret{Value: 2})
+		assert.NoError(err)
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{replicated}, delta.Spawned)
+		assert.Len(delta.Entities, 1)
+		assert.Len(delta.Entities[0].Added, 1)
+
+		secret, err := Get1[*trackedSecret](world, replicated)
+		assert.NoError(err)
+		secret.Value = 10
+
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.True(delta.IsEmpty(), delta.String())
+
+		// an entity that no longer has any tracked components is no longer tracked
+		assert.NoError(Remove1[trackedPosition](world, replicated))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{replicated}, delta.Despawned)
+	})
+
+	t.Run("only compares components that got changed according to change detection", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		tracker := NewDeltaTracker(nil)
+
+		entity, err := Spawn(world, &trackedPosition{X: 1})
+		assert.NoError(err)
+		_, err = tracker.Next(world)
+		assert.NoError(err)
+
+		position, err := Get1[*trackedPosition](world, entity)
+		assert.NoError(err)
+		position.X = 2
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.True(delta.IsEmpty(), delta.String())
+
+		query := Query1[*trackedPosition, Default]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Len(delta.Entities, 1)
+		assert.Equal(2, delta.Entities[0].Changed[0].value.Interface().(trackedPosition).X)
+
+		// all components are compared with the state of another world
+		otherWorld := NewDefaultWorld()
+		delta, err = tracker.Next(otherWorld)
+		assert.NoError(err)
+		assert.Equal([]EntityId{entity}, delta.Despawned)
+	})
+
+	t.Run("returns changes that systems make after it in the same schedule", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{id: 1}
+		tracker := NewDeltaTracker(nil)
+
+		_, err := Spawn(world, &trackedPosition{X: 1})
+		assert.NoError(err)
+
+		deltas := []WorldDelta{}
+		err = scheduleSystems.add(Systems(
+			func(world *World) error {
+				delta, err := tracker.Next(world)
+				deltas = append(deltas, delta)
+				return err
+			},
+			func(query *Query1[*trackedPosition, Lazy]) error {
+				if len(deltas) > 1 {
+					return nil
+				}
+
+				err := query.Exec(world)
+				query.Iter(func(entityId EntityId, position *trackedPosition) {
+					position.X++
+				})
+				return err
+			},
+		), "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		for tick := range 3 {
+			assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, uint(tick)))
+		}
+
+		assert.Len(deltas, 3)
+		assert.Len(deltas[0].Spawned, 1)
+		assert.Len(deltas[1].Entities, 1)
+		assert.Len(deltas[1].Entities[0].Changed, 1)
+		assert.True(deltas[2].IsEmpty(), deltas[2].String())
+	})
+
+	t.Run("returns the changes of restoring a binary snapshot", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		tracker := NewDeltaTracker(nil)
+
+		entity, err := Spawn(world, &trackedPosition{X: 1})
+		assert.NoError(err)
+		_, err = tracker.Next(world)
+		assert.NoError(err)
+
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		position, err := GetMut[trackedPosition](world, entity)
+		assert.NoError(err)
+		position.Set(trackedPosition{X: 2})
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Len(delta.Entities, 1)
+		assert.Equal(2, delta.Entities[0].Changed[0].value.Interface().(trackedPosition).X)
+
+		assert.NoError(RestoreBinary(world, data))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Len(delta.Entities, 1)
+		assert.Len(delta.Entities[0].Changed, 1)
+		assert.Equal(1, delta.Entities[0].Changed[0].value.Interface().(trackedPosition).X)
+
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.True(delta.IsEmpty(), delta.String())
+	})
+
+	t.Run("is marked as replicated by embedding Replicated", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		assert.True(IsReplicated(ComponentIdFor[trackedPosition](world)))
+		assert.False(IsReplicated(ComponentIdFor[trackedSecret](world)))
+	})
+}
+
+func TestWorldDeltaBinary(t *testing.T) {
+	type binaryDeltaPosition struct {
+		Component
+		X int
+	}
+	type binaryDeltaTargets struct{ Relation }
+
+	assert := assert.New(t)
+	world := NewDefaultWorld()
+
+	entity, err := Spawn(world, &binaryDeltaPosition{X: 1})
+	assert.NoError(err)
+	target, err := Spawn(world)
+	assert.NoError(err)
+	assert.NoError(InsertRelation(world, entity, target, &binaryDeltaTargets{}))
+	from, err := SnapshotBinary(world)
+	assert.NoError(err)
+
+	position, err := Get1[*binaryDeltaPosition](world, entity)
+	assert.NoError(err)
+	position.X = 2
+	assert.NoError(RemoveRelation[binaryDeltaTargets](world, entity, target))
+	assert.NoError(Despawn(world, target))
+	_, err = Spawn(world, &binaryDeltaPosition{X: 3})
+	assert.NoError(err)
+
+	delta, err := DiffWorld(world, from)
+	assert.NoError(err)
+	data, err := delta.MarshalBinary()
+	assert.NoError(err)
+
+	decoded := WorldDelta{}
+	assert.NoError(decoded.UnmarshalBinary(data))
+	assert.Equal(delta.Spawned, decoded.Spawned)
+	assert.Equal(delta.Despawned, decoded.Despawned)
+	assert.Len(decoded.Entities, len(delta.Entities))
+	for i, entityDelta := range delta.Entities {
+		for j, component := range entityDelta.Changed {
+			assert.Equal(component.Type, decoded.Entities[i].Changed[j].Type)
+			assert.Equal(component.Value, decoded.Entities[i].Changed[j].Value)
+		}
+		for j, component := range entityDelta.Removed {
+			assert.Equal(component.Type, decoded.Entities[i].Removed[j].Type)
+			assert.Equal(component.Target, decoded.Entities[i].Removed[j].Target)
+			assert.Nil(decoded.Entities[i].Removed[j].Value)
+		}
+	}
+
+	for length := range len(data) {
+		assert.ErrorIs(decoded.UnmarshalBinary(data[:length]), ErrSnapshotNotValid)
+	}
+	assert.ErrorIs(decoded.UnmarshalBinary(append(data, 0)), ErrSnapshotNotValid)
+}
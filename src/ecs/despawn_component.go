@@ -1,37 +1,60 @@
 package ecs
 
-import "fmt"
+import "errors"
 
-// Despawn removes an entity from the world.
+// Despawn removes an entity from the world. The entity is removed from the [Children] of its parent, and
+// its children lose their [Parent] component. Use [DespawnRecursive] to despawn the children as well.
+//
+// Relations that target the entity are removed from their source entities, see [Relation].
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity did not exist in the world.
+//   - ErrEntityStale error if the entity was already despawned.
 //   - ErrWorldIsLocked error while querying
 func Despawn(world *World, entity EntityId) error {
-	if world.isQuerying {
+	if world.isQuerying() {
 		// Prevent messing with query results
 		return ErrWorldIsLocked
 	}
 
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return ErrEntityNotFound
+	return despawn(world, entity, true)
+}
+
+// despawn removes an entity from the world. If updateHierarchy is true, the parent and the children of the
+// entity are updated to no longer point to the entity.
+func despawn(world *World, entity EntityId, updateHierarchy bool) error {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return err
 	}
 
 	componentIds := entityData.archetype.componentIds
+	entityObservers := entityData.observers
+
+	hierarchyRemoval := hierarchyRemoval{parent: nonExistingEntity}
+	if updateHierarchy {
+		hierarchyRemoval = prepareHierarchyRemoval(world, entity, entityData.archetype, componentIds)
+	}
 
-	err := entityData.archetype.removeEntity(entity)
+	err = removeEntityFromArchetype(world, entityData.archetype, entityData.row)
 	if err != nil {
-		return fmt.Errorf("failed to remove entity from archetype: %w", err)
+		return err
 	}
 
-	delete(world.archetypeStorage.entityIdToArchetype, entity)
-	delete(world.entities, entity)
+	err = world.entities.remove(entity)
+	if err != nil {
+		return err
+	}
+
+	world.removedComponents.record(world, entity, componentIds)
+
+	hierarchyErr := hierarchyRemoval.apply(world, entity)
+	relationsErr := removeRelationsTo(world, entity)
 
 	world.observers.triggerDespawnObservers(world, componentIds, entity)
-	if entityData.observers != nil {
-		entityData.observers.triggerDespawnObservers(world, componentIds, entity)
+	if entityObservers != nil {
+		entityObservers.triggerDespawnObservers(world, componentIds, entity)
 	}
 
-	return nil
+	return errors.Join(hierarchyErr, relationsErr)
 }
@@ -38,4 +38,33 @@ func TestDespawn(t *testing.T) {
 		_, err = Get1[structA](world, entity3)
 		assert.NoError(err)
 	})
+
+	t.Run("removes the components of the entity", func(t *testing.T) {
+		type structA struct {
+			Component
+			value int
+		}
+
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity1, err := Spawn(world, &structA{value: 1})
+		assert.NoError(err)
+		entity2, err := Spawn(world, &structA{value: 2})
+		assert.NoError(err)
+		entity3, err := Spawn(world, &structA{value: 3})
+		assert.NoError(err)
+
+		err = Despawn(world, entity1)
+		assert.NoError(err)
+		assert.Equal(2, world.CountComponents())
+
+		// entity3 got moved to the spot of entity1
+		a, err := Get1[structA](world, entity2)
+		assert.NoError(err)
+		assert.Equal(2, a.value)
+		a, err = Get1[structA](world, entity3)
+		assert.NoError(err)
+		assert.Equal(3, a.value)
+	})
 }
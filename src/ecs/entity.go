@@ -1,10 +1,55 @@
 package ecs
 
-type EntityId = uint
+import (
+	"fmt"
+	"sync"
+)
 
-// This entityId can never exist in `world` because the inserted entityId's starts at 1.
+// EntityId identifies an entity in a [World].
+//
+// An EntityId consists of an index and a generation. The index points to a slot in the entity table of
+// the world. When an entity gets despawned, its slot is recycled for a newly spawned entity, but with
+// an increased generation. This lets the world tell apart a stale EntityId from the entity that
+// currently lives in the same slot.
+type EntityId struct {
+	index      uint32
+	generation uint32
+}
+
+// This entityId can never exist in `world` because the entity table its first slot is never used.
 // Useful for tests.
-const nonExistingEntity = EntityId(0)
+var nonExistingEntity = EntityId{}
+
+// Index returns the index of the slot in the entity table of the world.
+func (entity EntityId) Index() uint32 {
+	return entity.index
+}
+
+// Generation returns how many times the slot of this entity has been recycled before this entity was spawned.
+func (entity EntityId) Generation() uint32 {
+	return entity.generation
+}
+
+func (entity EntityId) String() string {
+	return fmt.Sprintf("%d:%d", entity.index, entity.generation)
+}
+
+// MarshalText encodes the entity id in the same format as [EntityId.String], such as "3:0". This also makes
+// entity ids, including map keys, encode as strings in formats such as JSON.
+func (entity EntityId) MarshalText() ([]byte, error) {
+	return []byte(entity.String()), nil
+}
+
+// UnmarshalText decodes an entity id that got encoded by [EntityId.MarshalText].
+func (entity *EntityId) UnmarshalText(data []byte) error {
+	var result EntityId
+	if _, err := fmt.Sscanf(string(data), "%d:%d", &result.index, &result.generation); err != nil {
+		return fmt.Errorf("invalid entity id %q: %w", data, err)
+	}
+
+	*entity = result
+	return nil
+}
 
 type EntityData struct {
 	archetype *Archetype
@@ -16,9 +61,183 @@ func (e *EntityData) hasComponent(c ComponentId) bool {
 	return e.archetype.HasComponent(c)
 }
 
+type entitySlot struct {
+	data       EntityData
+	generation uint32
+	isAlive    bool
+	isReserved bool // reserved slots are not alive yet, and are not reused for other entities
+}
+
+// entityStorage is a dense table of entities. Slots of despawned entities are reused for new entities.
+type entityStorage struct {
+	slots            []entitySlot
+	freeIndices      []uint32
+	numberOfEntities int
+
+	// reservationMutex guards freeIndices and numberOfPendingSlots, so that entities can be reserved
+	// while systems run in parallel.
+	reservationMutex sync.Mutex
+
+	// The number of slots that got reserved past the end of slots. They are appended to slots by
+	// flushReservations.
+	numberOfPendingSlots int
+}
+
+func newEntityStorage() entityStorage {
+	return entityStorage{
+		// The first slot is reserved so that the zero value of EntityId never points to an entity.
+		slots: []entitySlot{{}},
+	}
+}
+
+// create reserves a slot for a new entity and returns its id and its data.
+//
+// The returned pointer is invalidated by the next call to create.
+func (storage *entityStorage) create() (EntityId, *EntityData) {
+	storage.reservationMutex.Lock()
+	defer storage.reservationMutex.Unlock()
+	storage.flushReservations()
+
+	var index uint32
+	if len(storage.freeIndices) > 0 {
+		index = storage.freeIndices[len(storage.freeIndices)-1]
+		storage.freeIndices = storage.freeIndices[:len(storage.freeIndices)-1]
+	} else {
+		index = uint32(len(storage.slots))
+		storage.slots = append(storage.slots, entitySlot{})
+	}
+
+	slot := &storage.slots[index]
+	slot.isAlive = true
+	storage.numberOfEntities++
+
+	return EntityId{index: index, generation: slot.generation}, &slot.data
+}
+
+// reserve returns the id of an entity that does not exist yet, and that can later be spawned with
+// createReserved. No other entity will get the returned id.
+//
+// reserve is safe to call while the storage is being read, for example from systems that run in parallel.
+func (storage *entityStorage) reserve() EntityId {
+	storage.reservationMutex.Lock()
+	defer storage.reservationMutex.Unlock()
+
+	if len(storage.freeIndices) > 0 {
+		index := storage.freeIndices[len(storage.freeIndices)-1]
+		storage.freeIndices = storage.freeIndices[:len(storage.freeIndices)-1]
+		storage.slots[index].isReserved = true
+		return EntityId{index: index, generation: storage.slots[index].generation}
+	}
+
+	index := uint32(len(storage.slots) + storage.numberOfPendingSlots)
+	storage.numberOfPendingSlots++
+	return EntityId{index: index, generation: 0}
+}
+
+// flushReservations appends the slots that got reserved past the end of slots. reservationMutex must be locked.
+func (storage *entityStorage) flushReservations() {
+	for range storage.numberOfPendingSlots {
+		storage.slots = append(storage.slots, entitySlot{isReserved: true})
+	}
+	storage.numberOfPendingSlots = 0
+}
+
+// isReserved returns whether entity got reserved and has not been created or released yet.
+func (storage *entityStorage) isReserved(entity EntityId) bool {
+	storage.reservationMutex.Lock()
+	defer storage.reservationMutex.Unlock()
+	storage.flushReservations()
+
+	if entity.index == 0 || int(entity.index) >= len(storage.slots) {
+		return false
+	}
+
+	slot := &storage.slots[entity.index]
+	return slot.isReserved && slot.generation == entity.generation
+}
+
+// createReserved creates the entity that got reserved with reserve.
+//
+// The returned pointer is invalidated by the next call to create.
+//
+// Can return the following errors:
+//   - ErrEntityNotReserved if entity is not reserved
+func (storage *entityStorage) createReserved(entity EntityId) (*EntityData, error) {
+	if !storage.isReserved(entity) {
+		return nil, ErrEntityNotReserved
+	}
+
+	slot := &storage.slots[entity.index]
+	slot.isReserved = false
+	slot.isAlive = true
+	storage.numberOfEntities++
+
+	return &slot.data, nil
+}
+
+// release frees up the slot of an entity that got reserved but that will not be created.
+//
+// Can return the following errors:
+//   - ErrEntityNotReserved if entity is not reserved
+func (storage *entityStorage) release(entity EntityId) error {
+	if !storage.isReserved(entity) {
+		return ErrEntityNotReserved
+	}
+
+	storage.reservationMutex.Lock()
+	defer storage.reservationMutex.Unlock()
+
+	slot := &storage.slots[entity.index]
+	slot.isReserved = false
+	slot.generation++
+	storage.freeIndices = append(storage.freeIndices, entity.index)
+
+	return nil
+}
+
+// get returns the data of entity.
+//
+// The returned pointer is invalidated by the next call to create.
+//
+// Can return the following errors:
+//   - ErrEntityNotFound if entity never existed
+//   - ErrEntityStale if entity has been despawned
+func (storage *entityStorage) get(entity EntityId) (*EntityData, error) {
+	if entity.index == 0 || int(entity.index) >= len(storage.slots) {
+		return nil, ErrEntityNotFound
+	}
+
+	slot := &storage.slots[entity.index]
+	if !slot.isAlive || slot.generation != entity.generation {
+		return nil, ErrEntityStale
+	}
+
+	return &slot.data, nil
+}
+
+// remove frees up the slot of entity so that it can be reused. The generation of the slot is increased
+// so that entity can no longer be used to retrieve the entity that will reuse the slot.
+func (storage *entityStorage) remove(entity EntityId) error {
+	if _, err := storage.get(entity); err != nil {
+		return err
+	}
+
+	slot := &storage.slots[entity.index]
+	slot.data = EntityData{}
+	slot.isAlive = false
+	slot.generation++
+	storage.numberOfEntities--
+
+	storage.reservationMutex.Lock()
+	storage.freeIndices = append(storage.freeIndices, entity.index)
+	storage.reservationMutex.Unlock()
+
+	return nil
+}
+
 // EntityExists returns whether the entity is currently in the world.
 // It returns false if the entity did exist but got despawned.
 func EntityExists(world *World, entity EntityId) bool {
-	_, exists := world.entities[entity]
-	return exists
+	_, err := world.entities.get(entity)
+	return err == nil
 }
@@ -38,4 +38,178 @@ func TestEntityExists(t *testing.T) {
 
 		assert.False(EntityExists(world, entity))
 	})
+
+	t.Run("returns false for a despawned entity whose slot got reused", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		err = Despawn(world, entity)
+		assert.NoError(err)
+
+		newEntity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.Equal(entity.Index(), newEntity.Index())
+
+		assert.False(EntityExists(world, entity))
+		assert.True(EntityExists(world, newEntity))
+	})
+}
+
+func TestEntityStorage(t *testing.T) {
+	t.Run("reuses the slot of removed entities with an increased generation", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		entity1, _ := storage.create()
+		entity2, _ := storage.create()
+		assert.NotEqual(entity1.Index(), entity2.Index())
+		assert.NotEqual(nonExistingEntity.Index(), entity1.Index())
+
+		assert.NoError(storage.remove(entity1))
+		entity3, _ := storage.create()
+		assert.Equal(entity1.Index(), entity3.Index())
+		assert.Equal(entity1.Generation()+1, entity3.Generation())
+		assert.Equal(2, storage.numberOfEntities)
+	})
+
+	t.Run("get returns an error for entities that never existed", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		_, err := storage.get(nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+		_, err = storage.get(EntityId{index: 10})
+		assert.ErrorIs(err, ErrEntityNotFound)
+	})
+
+	t.Run("get returns an error for stale entities", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		entity, _ := storage.create()
+		assert.NoError(storage.remove(entity))
+
+		_, err := storage.get(entity)
+		assert.ErrorIs(err, ErrEntityStale)
+		assert.ErrorIs(err, ErrEntityNotFound)
+
+		_, _ = storage.create()
+		_, err = storage.get(entity)
+		assert.ErrorIs(err, ErrEntityStale)
+	})
+
+	t.Run("remove returns an error for stale entities", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		entity, _ := storage.create()
+		assert.NoError(storage.remove(entity))
+		assert.ErrorIs(storage.remove(entity), ErrEntityStale)
+		assert.Equal(0, storage.numberOfEntities)
+	})
+
+	t.Run("reserved entities are not reused and can be created later", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		reserved := storage.reserve()
+		created, _ := storage.create()
+		assert.NotEqual(reserved.Index(), created.Index())
+
+		_, err := storage.get(reserved)
+		assert.ErrorIs(err, ErrEntityNotFound)
+
+		_, err = storage.createReserved(reserved)
+		assert.NoError(err)
+		_, err = storage.get(reserved)
+		assert.NoError(err)
+		assert.Equal(2, storage.numberOfEntities)
+
+		_, err = storage.createReserved(reserved)
+		assert.ErrorIs(err, ErrEntityNotReserved)
+	})
+
+	t.Run("reserves the slots of removed entities", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		entity, _ := storage.create()
+		assert.NoError(storage.remove(entity))
+
+		reserved := storage.reserve()
+		assert.Equal(entity.Index(), reserved.Index())
+		assert.Equal(entity.Generation()+1, reserved.Generation())
+	})
+
+	t.Run("released entities can not be created", func(t *testing.T) {
+		assert := assert.New(t)
+		storage := newEntityStorage()
+
+		reserved := storage.reserve()
+		assert.NoError(storage.release(reserved))
+		assert.ErrorIs(storage.release(reserved), ErrEntityNotReserved)
+
+		_, err := storage.createReserved(reserved)
+		assert.ErrorIs(err, ErrEntityNotReserved)
+
+		reused := storage.reserve()
+		assert.Equal(reserved.Index(), reused.Index())
+		assert.NotEqual(reserved.Generation(), reused.Generation())
+	})
+}
+
+func TestStaleEntity(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+
+	setup := func(assert *assert.Assertions) (world *World, staleEntity EntityId) {
+		world = NewDefaultWorld()
+
+		staleEntity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.NoError(Despawn(world, staleEntity))
+
+		// reuses the slot of staleEntity
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		return world, staleEntity
+	}
+
+	t.Run("Get1 returns ErrEntityStale", func(t *testing.T) {
+		assert := assert.New(t)
+		world, staleEntity := setup(assert)
+
+		_, err := Get1[componentA](world, staleEntity)
+		assert.ErrorIs(err, ErrEntityStale)
+	})
+
+	t.Run("Insert returns ErrEntityStale", func(t *testing.T) {
+		assert := assert.New(t)
+		world, staleEntity := setup(assert)
+
+		err := Insert(world, staleEntity, &componentB{})
+		assert.ErrorIs(err, ErrEntityStale)
+		assert.Equal(1, world.CountComponents())
+	})
+
+	t.Run("Remove1 returns ErrEntityStale", func(t *testing.T) {
+		assert := assert.New(t)
+		world, staleEntity := setup(assert)
+
+		err := Remove1[componentA](world, staleEntity)
+		assert.ErrorIs(err, ErrEntityStale)
+		assert.Equal(1, world.CountComponents())
+	})
+
+	t.Run("Despawn returns ErrEntityStale", func(t *testing.T) {
+		assert := assert.New(t)
+		world, staleEntity := setup(assert)
+
+		err := Despawn(world, staleEntity)
+		assert.ErrorIs(err, ErrEntityStale)
+		assert.Equal(1, world.CountEntities())
+	})
 }
@@ -1,14 +1,36 @@
 package ecs
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrEntityNotFound error = errors.New("entity not found")
+	ErrEntityNotFound    error = errors.New("entity not found")
+	ErrEntityStale       error = fmt.Errorf("%w: entity has been despawned", ErrEntityNotFound)
+	ErrEntityNotReserved error = errors.New("entity is not reserved")
 
 	ErrComponentNotFound       error = errors.New("component not found")
 	ErrComponentDuplicate      error = errors.New("duplicate component")
 	ErrComponentAlreadyPresent error = errors.New("component is already present")
 	ErrComponentIsNil          error = errors.New("component is nil")
+	ErrComponentTypeNotValid   error = errors.New("component type not valid")
+	ErrMutPointerComponent     error = errors.New("component of Mut can not be a pointer")
+
+	ErrParentNotFound error = errors.New("parent not found")
+	ErrHierarchyCycle error = errors.New("entity can not be its own ancestor")
+
+	ErrComponentIsRelation    error = errors.New("relations must be inserted with InsertRelation")
+	ErrRelationTargetNotFound error = errors.New("relation target not found")
+
+	ErrPrefabNameEmpty     error = errors.New("prefab name is empty")
+	ErrPrefabAlreadyExists error = errors.New("prefab already exists")
+	ErrPrefabNotFound      error = errors.New("prefab not found")
+	ErrPrefabCycle         error = errors.New("prefab contains itself")
+
+	ErrSnapshotTypeNotRegistered error = errors.New("type is not registered")
+	ErrSnapshotTypeNotSupported  error = errors.New("type can not be stored in a snapshot")
+	ErrSnapshotNotValid          error = errors.New("snapshot is not valid")
 
 	ErrResourceAlreadyPresent error = errors.New("resource already present")
 	ErrResourceIsNil          error = errors.New("resource is nil")
@@ -20,20 +42,29 @@ var (
 	ErrComponentStorageIndexOutOfBounds error = errors.New("component storage index is out of bounds")
 
 	ErrUnexpectedNumberOfQueryResults error = errors.New("unexpected number of query results")
+	ErrQueryIsZeroCopy                error = errors.New("not supported for zero-copy queries")
+	ErrQueryChunkPointerComponent     error = errors.New("chunks can not contain pointer components")
+	ErrQueryChunkNotZeroCopy          error = errors.New("chunks require the ZeroCopy query option")
+	ErrQueryChangeFilterNotSupported  error = errors.New("change filters (Added, Changed) are not supported")
+	ErrQueryInvalidComponentId        error = errors.New("invalid component id")
+	ErrQueryComponentNotInQuery       error = errors.New("component is not in query")
 
 	ErrTargetWorldNotFound error = errors.New("target world not found")
+	ErrTransferToSameWorld error = errors.New("source and destination world are the same")
+
+	ErrSystemTypeNotValid             error = errors.New("system type is not valid")
+	ErrSystemNotAFunction             error = errors.New("not a function")
+	ErrSystemInvalidReturnType        error = errors.New("invalid return type(s)")
+	ErrSystemParamQueryNotAPointer    error = errors.New("query must be a pointer")
+	ErrSystemParamQueryNotValid       error = errors.New("query param not valid")
+	ErrSystemParamWorldNotAPointer    error = errors.New("world must be a pointer")
+	ErrSystemParamCommandsNotAPointer error = errors.New("commands must be a pointer")
+	ErrSystemParamNotValid            error = errors.New("not valid")
 
-	ErrSystemTypeNotValid          error = errors.New("system type is not valid")
-	ErrSystemNotAFunction          error = errors.New("not a function")
-	ErrSystemInvalidReturnType     error = errors.New("invalid return type(s)")
-	ErrSystemParamQueryNotAPointer error = errors.New("query must be a pointer")
-	ErrSystemParamQueryNotValid    error = errors.New("query param not valid")
-	ErrSystemParamWorldNotAPointer error = errors.New("world must be a pointer")
-	ErrSystemParamNotValid         error = errors.New("not valid")
-
-	ErrSystemParamEventReaderNotAPointer  error = errors.New("must be a pointer")
-	ErrSystemParamEventWriterNotAPointer  error = errors.New("must be a pointer")
-	ErrSystemParamOuterResourceIsAPointer error = errors.New("OuterResource must not be a pointer")
+	ErrSystemParamEventReaderNotAPointer       error = errors.New("must be a pointer")
+	ErrSystemParamEventWriterNotAPointer       error = errors.New("must be a pointer")
+	ErrSystemParamRemovedComponentsNotAPointer error = errors.New("must be a pointer")
+	ErrSystemParamOuterResourceIsAPointer      error = errors.New("OuterResource must not be a pointer")
 
 	ErrScheduleAlreadyExists error = errors.New("schedule already exists")
 	ErrScheduleNotFound      error = errors.New("schedule not found")
@@ -74,6 +74,78 @@ func (s *EventStorage) ProcessEvents(scheduleSystemsId ScheduleSystemsId, curren
 	}
 }
 
+// EventsSnapshot is a copy of the events of an [EventStorage], see [EventStorage.Snapshot].
+type EventsSnapshot struct {
+	readerEvents map[reflect.Type]any
+	writerEvents map[reflect.Type]any
+}
+
+// eventBuffer is implemented by [EventReader] and [EventWriter] so that their events can be copied and restored.
+type eventBuffer interface {
+	copyEvents() any
+	restoreEvents(events any)
+}
+
+// Snapshot returns a copy of the events of all event readers and event writers. Events are copied shallowly, so
+// that events that get marked as removed after taking the snapshot are not marked as removed in the snapshot.
+func (s *EventStorage) Snapshot() EventsSnapshot {
+	snapshot := EventsSnapshot{
+		readerEvents: make(map[reflect.Type]any, len(s.eventReaders)),
+		writerEvents: make(map[reflect.Type]any, len(s.eventWriters)),
+	}
+
+	for eventId, reader := range s.eventReaders {
+		if buffer, ok := reflect.TypeAssert[eventBuffer](*reader); ok {
+			snapshot.readerEvents[eventId] = buffer.copyEvents()
+		}
+	}
+
+	for eventId, writer := range s.eventWriters {
+		if buffer, ok := reflect.TypeAssert[eventBuffer](*writer); ok {
+			snapshot.writerEvents[eventId] = buffer.copyEvents()
+		}
+	}
+
+	return snapshot
+}
+
+// RestoreSnapshot replaces the events of all event readers and event writers by the events of snapshot. Event
+// readers and event writers that did not exist when the snapshot got taken are emptied. A snapshot can be restored
+// multiple times.
+func (s *EventStorage) RestoreSnapshot(snapshot EventsSnapshot) {
+	restore := func(buffers map[reflect.Type]*reflect.Value, snapshotEvents map[reflect.Type]any) {
+		for eventId, value := range buffers {
+			buffer, ok := reflect.TypeAssert[eventBuffer](*value)
+			if !ok {
+				continue
+			}
+
+			buffer.restoreEvents(snapshotEvents[eventId])
+		}
+	}
+
+	restore(s.eventReaders, snapshot.readerEvents)
+	restore(s.eventWriters, snapshot.writerEvents)
+}
+
+// copyEvents returns a copy of events in which events that are pointers point to copies of the events.
+func copyEvents[E IEvent](events []E) []E {
+	result := make([]E, len(events))
+	for i, event := range events {
+		value := reflect.ValueOf(event)
+		if value.Kind() != reflect.Pointer || value.IsNil() {
+			result[i] = event
+			continue
+		}
+
+		eventCopy := reflect.New(value.Type().Elem())
+		eventCopy.Elem().Set(value.Elem())
+		result[i] = eventCopy.Interface().(E)
+	}
+
+	return result
+}
+
 type IEvent interface {
 	shouldRemove() bool
 	getScheduleSystemsWriter() ScheduleSystemsId
@@ -181,6 +253,15 @@ func (writer *EventWriter[E]) SetScheduleSystemsWriter(id ScheduleSystemsId) {
 	writer.ScheduleSystemsId = id
 }
 
+func (writer *EventWriter[E]) copyEvents() any {
+	return copyEvents(writer.events)
+}
+
+func (writer *EventWriter[E]) restoreEvents(events any) {
+	snapshotEvents, _ := events.([]E)
+	writer.events = copyEvents(snapshotEvents)
+}
+
 type AnyEventWriter interface {
 	WriterEventId() reflect.Type
 	ExtractEvents(tick uint) []reflect.Value
@@ -261,6 +342,15 @@ func (reader *EventReader[E]) ReaderEventId() reflect.Type {
 	return reflect.TypeFor[E]()
 }
 
+func (reader *EventReader[E]) copyEvents() any {
+	return copyEvents(reader.events)
+}
+
+func (reader *EventReader[E]) restoreEvents(events any) {
+	snapshotEvents, _ := events.([]E)
+	reader.events = copyEvents(snapshotEvents)
+}
+
 // ClearEvents removes all events that satisfy one of the following:
 //   - marked to be removed
 //   - written by [ScheduleSystems] with given [ScheduleSystemsId] AND added to reader at least 1 tick back
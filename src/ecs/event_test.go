@@ -208,3 +208,56 @@ func TestEventReader(t *testing.T) {
 		}
 	})
 }
+
+func TestEventStorageSnapshot(t *testing.T) {
+	type testEvent struct {
+		Event
+		id int
+	}
+	type otherEvent struct{ Event }
+
+	t.Run("restores the events of readers and writers", func(t *testing.T) {
+		assert := assert.New(t)
+
+		storage := NewEventStorage()
+		reader := &EventReader[*testEvent]{}
+		writer := &EventWriter[*testEvent]{}
+		storage.GetReader(reader)
+		storage.GetWriter(writer)
+
+		reader.events = []*testEvent{{id: 1}}
+		writer.Write(&testEvent{id: 2})
+
+		snapshot := storage.Snapshot()
+
+		reader.events[0].Remove()
+		reader.events = append(reader.events, &testEvent{id: 3})
+		writer.Write(&testEvent{id: 4})
+
+		storage.RestoreSnapshot(snapshot)
+		assert.Equal(1, reader.Len())
+		event, _ := reader.First()
+		assert.Equal(1, event.id)
+		assert.Len(writer.events, 1)
+		assert.Equal(2, writer.events[0].id)
+
+		// restoring again gives the same result, even after changing the restored events
+		event.Remove()
+		storage.RestoreSnapshot(snapshot)
+		assert.Equal(1, reader.Len())
+	})
+
+	t.Run("empties readers and writers that did not exist when taking the snapshot", func(t *testing.T) {
+		assert := assert.New(t)
+
+		storage := NewEventStorage()
+		snapshot := storage.Snapshot()
+
+		reader := &EventReader[*otherEvent]{}
+		storage.GetReader(reader)
+		reader.events = []*otherEvent{{}}
+
+		storage.RestoreSnapshot(snapshot)
+		assert.True(reader.IsEmpty())
+	})
+}
@@ -7,13 +7,14 @@ import "reflect"
 //
 // Can return the following errors:
 //   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
 //   - Returns an ErrComponentNotFound error if the entity does not have the component.
 //
 // WARNING: Do not store the component pointer
 func Get1[A AnyComponent](world *World, entity EntityId) (a A, err error) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -27,15 +28,16 @@ func Get1[A AnyComponent](world *World, entity EntityId) (a A, err error) {
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
 //
 // WARNING: Do not store any of the component pointers
 func Get2[A, B AnyComponent](world *World, entity EntityId) (a A, b B, err error) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -51,15 +53,16 @@ func Get2[A, B AnyComponent](world *World, entity EntityId) (a A, b B, err error
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
 //
 // WARNING: Do not store any of the component pointers
 func Get3[A, B, C AnyComponent](world *World, entity EntityId) (a A, b B, c C, err error) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -77,15 +80,16 @@ func Get3[A, B, C AnyComponent](world *World, entity EntityId) (a A, b B, c C, e
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
 //
 // WARNING: Do not store any of the component pointers
 func Get4[A, B, C, D AnyComponent](world *World, entity EntityId) (a A, b B, c C, d D, err error) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -105,15 +109,16 @@ func Get4[A, B, C, D AnyComponent](world *World, entity EntityId) (a A, b B, c C
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
 //
 // WARNING: Do not store any of the component pointers
 func Get5[A, B, C, D, E AnyComponent](world *World, entity EntityId) (a A, b B, c C, d D, e E, err error) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -135,15 +140,16 @@ func Get5[A, B, C, D, E AnyComponent](world *World, entity EntityId) (a A, b B,
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
 //
 // WARNING: Do not store any of the component pointers
 func Get6[A, B, C, D, E, F AnyComponent](world *World, entity EntityId) (a A, b B, c C, d D, e E, f F, err error) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -167,6 +173,7 @@ func Get6[A, B, C, D, E, F AnyComponent](world *World, entity EntityId) (a A, b
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -175,9 +182,9 @@ func Get6[A, B, C, D, E, F AnyComponent](world *World, entity EntityId) (a A, b
 func Get7[A, B, C, D, E, F, G AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -203,6 +210,7 @@ func Get7[A, B, C, D, E, F, G AnyComponent](world *World, entity EntityId) (
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -211,9 +219,9 @@ func Get7[A, B, C, D, E, F, G AnyComponent](world *World, entity EntityId) (
 func Get8[A, B, C, D, E, F, G, H AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -241,6 +249,7 @@ func Get8[A, B, C, D, E, F, G, H AnyComponent](world *World, entity EntityId) (
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -249,9 +258,9 @@ func Get8[A, B, C, D, E, F, G, H AnyComponent](world *World, entity EntityId) (
 func Get9[A, B, C, D, E, F, G, H, I AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -281,6 +290,7 @@ func Get9[A, B, C, D, E, F, G, H, I AnyComponent](world *World, entity EntityId)
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -289,9 +299,9 @@ func Get9[A, B, C, D, E, F, G, H, I AnyComponent](world *World, entity EntityId)
 func Get10[A, B, C, D, E, F, G, H, I, J AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -323,6 +333,7 @@ func Get10[A, B, C, D, E, F, G, H, I, J AnyComponent](world *World, entity Entit
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -331,9 +342,9 @@ func Get10[A, B, C, D, E, F, G, H, I, J AnyComponent](world *World, entity Entit
 func Get11[A, B, C, D, E, F, G, H, I, J, K AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, k, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, k, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -367,6 +378,7 @@ func Get11[A, B, C, D, E, F, G, H, I, J, K AnyComponent](world *World, entity En
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -375,9 +387,9 @@ func Get11[A, B, C, D, E, F, G, H, I, J, K AnyComponent](world *World, entity En
 func Get12[A, B, C, D, E, F, G, H, I, J, K, L AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, k, l, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, k, l, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -413,6 +425,7 @@ func Get12[A, B, C, D, E, F, G, H, I, J, K, L AnyComponent](world *World, entity
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -421,9 +434,9 @@ func Get12[A, B, C, D, E, F, G, H, I, J, K, L AnyComponent](world *World, entity
 func Get13[A, B, C, D, E, F, G, H, I, J, K, L, M AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, k, l, m, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, k, l, m, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -461,6 +474,7 @@ func Get13[A, B, C, D, E, F, G, H, I, J, K, L, M AnyComponent](world *World, ent
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -469,9 +483,9 @@ func Get13[A, B, C, D, E, F, G, H, I, J, K, L, M AnyComponent](world *World, ent
 func Get14[A, B, C, D, E, F, G, H, I, J, K, L, M, N AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, k, l, m, n, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, k, l, m, n, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -511,6 +525,7 @@ func Get14[A, B, C, D, E, F, G, H, I, J, K, L, M, N AnyComponent](world *World,
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -519,9 +534,9 @@ func Get14[A, B, C, D, E, F, G, H, I, J, K, L, M, N AnyComponent](world *World,
 func Get15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, o O, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -563,6 +578,7 @@ func Get15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O AnyComponent](world *Worl
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the entity does not have any of the components.
 //
 // Returns the same component pointer multiple times if multiple component of the same type are given.
@@ -571,9 +587,9 @@ func Get15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O AnyComponent](world *Worl
 func Get16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P AnyComponent](world *World, entity EntityId) (
 	a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, o O, p P, err error,
 ) {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, err
 	}
 
 	if err = setComponentFromEntry(world, entityData, &a); err != nil {
@@ -613,6 +629,54 @@ func Get16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P AnyComponent](world *W
 	return a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, nil
 }
 
+// GetById returns a pointer to the component with id componentId that belongs to the given entity, such as
+// *Position for a Position component. Use this when the component type is only known at runtime, and [Get1]
+// otherwise.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
+//   - Returns an ErrComponentNotFound error if the entity does not have the component.
+//
+// WARNING: Do not store the component pointer
+func GetById(world *World, entity EntityId, componentId ComponentId) (AnyComponent, error) {
+	value, err := GetValueById(world, entity, componentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.Addr().Interface().(AnyComponent), nil
+}
+
+// GetValueById returns the component with id componentId that belongs to the given entity. The value is the
+// component in its component storage, so setting it changes the component. Such changes are not seen by the
+// [Changed] filter, use [InsertOrOverwriteValue] for that.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
+//   - Returns an ErrComponentNotFound error if the entity does not have the component.
+//
+// WARNING: Do not store the value
+func GetValueById(world *World, entity EntityId, componentId ComponentId) (reflect.Value, error) {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	storage, componentExists := entityData.archetype.components[componentId]
+	if !componentExists {
+		return reflect.Value{}, ErrComponentNotFound
+	}
+
+	componentPointer, err := storage.getComponentPointer(entityData.row)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return reflect.NewAt(storage.componentId.componentType, componentPointer).Elem(), nil
+}
+
 // If a component of type T exists in entry, make target point to that component.
 //
 // Can return the following errors:
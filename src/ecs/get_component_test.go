@@ -767,3 +767,72 @@ func TestGet16(t *testing.T) {
 		assert.Equal(expectedValueP, (*p).value)
 	})
 }
+
+func TestGetById(t *testing.T) {
+	type componentA struct {
+		Component
+		Value int
+	}
+	type componentB struct{ Component }
+
+	t.Run("returns the component as a pointer and as a value", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{Value: 1})
+		assert.NoError(err)
+		componentId := ComponentIdFor[componentA](world)
+
+		component, err := GetById(world, entity, componentId)
+		assert.NoError(err)
+		assert.Equal(&componentA{Value: 1}, component)
+
+		value, err := GetValueById(world, entity, componentId)
+		assert.NoError(err)
+		assert.Equal(componentA{Value: 1}, value.Interface())
+
+		// both point to the component in its storage
+		component.(*componentA).Value = 2
+		value.FieldByName("Value").SetInt(3)
+		a, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(3, a.Value)
+	})
+
+	t.Run("returns a relation pair", func(t *testing.T) {
+		type likes struct {
+			Relation
+			Amount int
+		}
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		target, err := Spawn(world)
+		assert.NoError(err)
+		entity, err := Spawn(world)
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, entity, target, &likes{Amount: 4}))
+
+		component, err := GetById(world, entity, RelationIdFor[likes](world, target))
+		assert.NoError(err)
+		assert.Equal(4, component.(*likes).Amount)
+	})
+
+	t.Run("returns an error if the entity does not have the component", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		_, err = GetById(world, entity, ComponentIdFor[componentB](world))
+		assert.ErrorIs(err, ErrComponentNotFound)
+		_, err = GetValueById(world, entity, ComponentId{})
+		assert.ErrorIs(err, ErrComponentNotFound)
+	})
+
+	t.Run("returns an error if the entity does not exist", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := GetById(world, nonExistingEntity, ComponentIdFor[componentA](world))
+		assert.ErrorIs(err, ErrEntityNotFound)
+	})
+}
@@ -1,13 +1,16 @@
 package ecs
 
+import "slices"
+
 // HasComponent returns whether entity has component C.
 //
 // Can return the following errors:
 //   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
 func HasComponent[C AnyComponent](world *World, entity EntityId) (bool, error) {
-	entityData, exists := world.entities[entity]
-	if !exists {
-		return false, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return false, err
 	}
 
 	return entityData.archetype.HasComponent(ComponentIdFor[C](world)), nil
@@ -19,11 +22,27 @@ func HasComponent[C AnyComponent](world *World, entity EntityId) (bool, error) {
 //
 // Can return the following errors:
 //   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
 func HasComponentId(world *World, entity EntityId, componentId ComponentId) (bool, error) {
-	entityData, exists := world.entities[entity]
-	if !exists {
-		return false, ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return false, err
 	}
 
 	return entityData.archetype.HasComponent(componentId), nil
 }
+
+// ComponentIdsOfEntity returns the ids of all components of entity, including relation pairs. The ids are in the
+// same order for all entities that have the same components.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
+func ComponentIdsOfEntity(world *World, entity EntityId) ([]ComponentId, error) {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(entityData.archetype.componentIds), nil
+}
@@ -81,3 +81,39 @@ func TestHasComponentId(t *testing.T) {
 		assert.True(result)
 	})
 }
+
+func TestComponentIdsOfEntity(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+	type likes struct{ Relation }
+
+	t.Run("returns the ids of all components of the entity", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		target, err := Spawn(world)
+		assert.NoError(err)
+		entity, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, entity, target, &likes{}))
+
+		componentIds, err := ComponentIdsOfEntity(world, entity)
+		assert.NoError(err)
+		assert.ElementsMatch([]ComponentId{
+			ComponentIdFor[componentA](world),
+			ComponentIdFor[componentB](world),
+			RelationIdFor[likes](world, target),
+		}, componentIds)
+
+		componentIds, err = ComponentIdsOfEntity(world, target)
+		assert.NoError(err)
+		assert.Empty(componentIds)
+	})
+
+	t.Run("returns an error if the entity does not exist", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := ComponentIdsOfEntity(world, nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+	})
+}
@@ -0,0 +1,309 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Parent is a component that makes an entity the child of another entity. The [Children] component of the
+// parent entity is kept up to date when Parent gets added with [Spawn], [Insert] or [InsertOrOverwrite], and
+// when it gets removed with [Remove1] and so on, or by despawning the entity.
+//
+// Changing Entity of an existing Parent component, for example through a pointer query, does not update
+// the Children of the parent. Use [InsertOrOverwrite] to move an entity to another parent instead.
+type Parent struct {
+	Component
+	Entity EntityId
+}
+
+// Children is a component that lists the entities that have a [Parent] component that points to this entity.
+// It gets added, updated and removed automatically when the Parent component of other entities changes.
+//
+// Removing Children from an entity, or despawning the entity, removes the Parent component of its children.
+// Use [DespawnRecursive] to despawn the children as well.
+type Children struct {
+	Component
+	entities []EntityId
+}
+
+// Entities returns the children, in the order in which they got their parent.
+func (c Children) Entities() []EntityId {
+	return slices.Clone(c.entities)
+}
+
+// Len returns the number of children.
+func (c Children) Len() int {
+	return len(c.entities)
+}
+
+// Ancestors returns the parent of entity, the parent of that parent and so on, up to the root of the hierarchy.
+//
+// Can return the following errors:
+//   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
+func Ancestors(world *World, entity EntityId) ([]EntityId, error) {
+	if _, err := world.entities.get(entity); err != nil {
+		return nil, err
+	}
+
+	result := []EntityId{}
+	for {
+		parent, hasParent := getParent(world, entity)
+		if !hasParent || slices.Contains(result, parent) {
+			return result, nil
+		}
+
+		result = append(result, parent)
+		entity = parent
+	}
+}
+
+// Descendants returns the children of entity, their children and so on, in depth-first order. Each entity
+// comes before its own descendants. Each entity is returned once, even if the hierarchy contains a cycle because
+// a Parent component got changed in place.
+//
+// Can return the following errors:
+//   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
+func Descendants(world *World, entity EntityId) ([]EntityId, error) {
+	if _, err := world.entities.get(entity); err != nil {
+		return nil, err
+	}
+
+	result := []EntityId{}
+	isVisited := map[EntityId]bool{entity: true}
+	var visit func(entity EntityId)
+	visit = func(entity EntityId) {
+		for _, child := range getChildren(world, entity) {
+			if isVisited[child] {
+				continue
+			}
+
+			isVisited[child] = true
+			result = append(result, child)
+			visit(child)
+		}
+	}
+	visit(entity)
+
+	return result, nil
+}
+
+// DespawnRecursive despawns entity and all of its descendants. Descendants are despawned before their
+// ancestors, so that OnDespawn observers of an entity can still access its parent. OnDespawn observers
+// are triggered for every despawned entity.
+//
+// Can return the following errors:
+//   - ErrEntityNotFound error if the entity did not exist in the world.
+//   - ErrEntityStale error if the entity was already despawned.
+//   - ErrWorldIsLocked error while querying
+func DespawnRecursive(world *World, entity EntityId) error {
+	if world.isQuerying() {
+		// Prevent messing with query results
+		return ErrWorldIsLocked
+	}
+
+	descendants, err := Descendants(world, entity)
+	if err != nil {
+		return err
+	}
+
+	var resultErr error
+	for _, descendant := range slices.Backward(descendants) {
+		if _, err := world.entities.get(descendant); err != nil {
+			// Already despawned, for example by an observer.
+			continue
+		}
+
+		// The whole subtree is despawned, so there is no need to keep it consistent in the meantime.
+		err = despawn(world, descendant, false)
+		if err != nil {
+			resultErr = errors.Join(resultErr, fmt.Errorf("failed to despawn descendant %s: %w", descendant, err))
+		}
+	}
+
+	return errors.Join(resultErr, Despawn(world, entity))
+}
+
+// parentChange describes how the Parent component of an entity changes, so that the Children of the
+// old and the new parent can be updated once the change has been made.
+type parentChange struct {
+	oldParent EntityId // nonExistingEntity if the entity did not have a parent
+	newParent EntityId // nonExistingEntity if the parent does not change
+}
+
+// prepareParentChange validates the Parent component in components, if there is any, before it gets inserted
+// into entity. Entity is nonExistingEntity for entities that are being spawned.
+//
+// Can return the following errors:
+//   - ErrParentNotFound error if the parent does not exist in world.
+//   - ErrHierarchyCycle error if the parent is entity itself or one of its descendants.
+func prepareParentChange(world *World, entity EntityId, components []AnyComponent) (parentChange, error) {
+	change := parentChange{oldParent: nonExistingEntity, newParent: nonExistingEntity}
+
+	newParent, hasParent := findParent(components)
+	if !hasParent {
+		return change, nil
+	}
+
+	if _, err := world.entities.get(newParent); err != nil {
+		return change, fmt.Errorf("%w: %w", ErrParentNotFound, err)
+	}
+
+	if entity != nonExistingEntity {
+		if newParent == entity {
+			return change, ErrHierarchyCycle
+		}
+
+		ancestors, err := Ancestors(world, newParent)
+		if err != nil {
+			return change, err
+		}
+		if slices.Contains(ancestors, entity) {
+			return change, ErrHierarchyCycle
+		}
+
+		if oldParent, hasOldParent := getParent(world, entity); hasOldParent {
+			change.oldParent = oldParent
+		}
+	}
+
+	change.newParent = newParent
+	return change, nil
+}
+
+// apply moves entity from the Children of its old parent to the Children of its new parent.
+func (c parentChange) apply(world *World, entity EntityId) error {
+	if c.newParent == nonExistingEntity || c.newParent == c.oldParent {
+		return nil
+	}
+
+	if c.oldParent != nonExistingEntity {
+		if err := removeChild(world, c.oldParent, entity); err != nil {
+			return err
+		}
+	}
+
+	return addChild(world, c.newParent, entity)
+}
+
+// hierarchyRemoval describes the hierarchy components that an entity is about to lose, so that its parent and
+// children can be updated once the components have been removed.
+type hierarchyRemoval struct {
+	parent   EntityId // nonExistingEntity if the entity does not lose its parent
+	children []EntityId
+}
+
+// prepareHierarchyRemoval captures the parent and the children of entity if componentIds contains the Parent
+// or the Children component respectively.
+func prepareHierarchyRemoval(world *World, entity EntityId, archetype *Archetype, componentIds []ComponentId) hierarchyRemoval {
+	removal := hierarchyRemoval{parent: nonExistingEntity}
+
+	if slices.Contains(componentIds, ComponentIdFor[Parent](world)) {
+		if parent, hasParent := getParent(world, entity); hasParent {
+			removal.parent = parent
+		}
+	}
+
+	if slices.Contains(componentIds, ComponentIdFor[Children](world)) && archetype.HasComponent(ComponentIdFor[Children](world)) {
+		removal.children = slices.Clone(getChildren(world, entity))
+	}
+
+	return removal
+}
+
+// apply removes entity from the Children of its parent, and removes the Parent component from its children.
+func (r hierarchyRemoval) apply(world *World, entity EntityId) error {
+	var resultErr error
+
+	if r.parent != nonExistingEntity {
+		resultErr = removeChild(world, r.parent, entity)
+	}
+
+	for _, child := range r.children {
+		if _, err := world.entities.get(child); err != nil {
+			continue
+		}
+
+		if parent, hasParent := getParent(world, child); !hasParent || parent != entity {
+			continue
+		}
+
+		err := removeComponents(world, child, []ComponentId{ComponentIdFor[Parent](world)})
+		if err != nil {
+			resultErr = errors.Join(resultErr, fmt.Errorf("failed to remove parent of child %s: %w", child, err))
+		}
+	}
+
+	return resultErr
+}
+
+// findParent returns the entity of the first Parent component in components.
+func findParent(components []AnyComponent) (EntityId, bool) {
+	for _, component := range components {
+		switch parent := component.(type) {
+		case *Parent:
+			return parent.Entity, true
+		case Parent:
+			return parent.Entity, true
+		}
+	}
+
+	return nonExistingEntity, false
+}
+
+// getParent returns the parent of entity, if it has one.
+func getParent(world *World, entity EntityId) (EntityId, bool) {
+	parent, err := Get1[Parent](world, entity)
+	if err != nil {
+		return nonExistingEntity, false
+	}
+
+	return parent.Entity, true
+}
+
+// getChildren returns the children of entity. The result must not be modified.
+func getChildren(world *World, entity EntityId) []EntityId {
+	children, err := Get1[Children](world, entity)
+	if err != nil {
+		return nil
+	}
+
+	return children.entities
+}
+
+// addChild adds child to the Children of parent, and inserts the Children component if parent does not have it yet.
+func addChild(world *World, parent EntityId, child EntityId) error {
+	children, err := GetMut[Children](world, parent)
+	if errors.Is(err, ErrComponentNotFound) {
+		return Insert(world, parent, &Children{entities: []EntityId{child}})
+	}
+	if err != nil {
+		return err
+	}
+
+	childrenPtr := children.Ptr()
+	childrenPtr.entities = append(childrenPtr.entities, child)
+	return nil
+}
+
+// removeChild removes child from the Children of parent, and removes the Children component if it becomes empty.
+// Nothing happens if parent no longer exists.
+func removeChild(world *World, parent EntityId, child EntityId) error {
+	children, err := GetMut[Children](world, parent)
+	if err != nil {
+		return nil
+	}
+
+	childrenPtr := children.Ptr()
+	childrenPtr.entities = slices.DeleteFunc(childrenPtr.entities, func(entity EntityId) bool {
+		return entity == child
+	})
+
+	if len(childrenPtr.entities) == 0 {
+		return removeComponents(world, parent, []ComponentId{ComponentIdFor[Children](world)})
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHierarchy(t *testing.T) {
+	type componentA struct{ Component }
+
+	getChildrenOf := func(world *World, entity EntityId) []EntityId {
+		children, err := Get1[Children](world, entity)
+		if err != nil {
+			return nil
+		}
+		return children.Entities()
+	}
+
+	t.Run("Spawn adds the entity to the children of its parent", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		childA, err := Spawn(world, &Parent{Entity: parent})
+		assert.NoError(err)
+		childB, err := Spawn(world, &componentA{}, &Parent{Entity: parent})
+		assert.NoError(err)
+
+		assert.Equal([]EntityId{childA, childB}, getChildrenOf(world, parent))
+	})
+
+	t.Run("Spawn returns an error if the parent does not exist", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Spawn(world, &Parent{Entity: nonExistingEntity})
+		assert.ErrorIs(err, ErrParentNotFound)
+		assert.Equal(0, world.CountEntities())
+	})
+
+	t.Run("Insert adds the entity to the children of its parent", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		child, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		assert.NoError(Insert(world, child, &Parent{Entity: parent}))
+		assert.Equal([]EntityId{child}, getChildrenOf(world, parent))
+	})
+
+	t.Run("Insert returns an error when the hierarchy would contain a cycle", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		root, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		child, err := Spawn(world, &Parent{Entity: root})
+		assert.NoError(err)
+		grandchild, err := Spawn(world, &Parent{Entity: child})
+		assert.NoError(err)
+
+		assert.ErrorIs(Insert(world, root, &Parent{Entity: root}), ErrHierarchyCycle)
+		assert.ErrorIs(Insert(world, root, &Parent{Entity: grandchild}), ErrHierarchyCycle)
+		assert.ErrorIs(InsertOrOverwrite(world, child, &Parent{Entity: grandchild}), ErrHierarchyCycle)
+
+		hasParent, err := HasComponent[Parent](world, root)
+		assert.NoError(err)
+		assert.False(hasParent)
+	})
+
+	t.Run("InsertOrOverwrite moves the entity to its new parent", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parentA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		parentB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		child, err := Spawn(world, &Parent{Entity: parentA})
+		assert.NoError(err)
+
+		assert.NoError(InsertOrOverwrite(world, child, &Parent{Entity: parentB}))
+		assert.Empty(getChildrenOf(world, parentA))
+		assert.Equal([]EntityId{child}, getChildrenOf(world, parentB))
+
+		hasChildren, err := HasComponent[Children](world, parentA)
+		assert.NoError(err)
+		assert.False(hasChildren)
+	})
+
+	t.Run("removing Parent removes the entity from the children of its parent", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		childA, err := Spawn(world, &Parent{Entity: parent})
+		assert.NoError(err)
+		childB, err := Spawn(world, &Parent{Entity: parent})
+		assert.NoError(err)
+
+		assert.NoError(Remove1[Parent](world, childA))
+		assert.Equal([]EntityId{childB}, getChildrenOf(world, parent))
+	})
+
+	t.Run("removing Children removes the parent of the children", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		child, err := Spawn(world, &Parent{Entity: parent})
+		assert.NoError(err)
+
+		assert.NoError(Remove1[Children](world, parent))
+		hasParent, err := HasComponent[Parent](world, child)
+		assert.NoError(err)
+		assert.False(hasParent)
+	})
+
+	t.Run("Despawn updates the parent and the children of the entity", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		root, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		childA, err := Spawn(world, &Parent{Entity: root})
+		assert.NoError(err)
+		childB, err := Spawn(world, &Parent{Entity: root})
+		assert.NoError(err)
+		grandchild, err := Spawn(world, &Parent{Entity: childA})
+		assert.NoError(err)
+
+		assert.NoError(Despawn(world, childA))
+		assert.Equal([]EntityId{childB}, getChildrenOf(world, root))
+
+		hasParent, err := HasComponent[Parent](world, grandchild)
+		assert.NoError(err)
+		assert.False(hasParent)
+	})
+
+	t.Run("Ancestors and Descendants walk the hierarchy", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		root, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		childA, err := Spawn(world, &Parent{Entity: root})
+		assert.NoError(err)
+		childB, err := Spawn(world, &Parent{Entity: root})
+		assert.NoError(err)
+		grandchild, err := Spawn(world, &Parent{Entity: childA})
+		assert.NoError(err)
+
+		ancestors, err := Ancestors(world, grandchild)
+		assert.NoError(err)
+		assert.Equal([]EntityId{childA, root}, ancestors)
+
+		ancestors, err = Ancestors(world, root)
+		assert.NoError(err)
+		assert.Empty(ancestors)
+
+		descendants, err := Descendants(world, root)
+		assert.NoError(err)
+		assert.Equal([]EntityId{childA, grandchild, childB}, descendants)
+
+		_, err = Ancestors(world, nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+		_, err = Descendants(world, nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+	})
+
+	t.Run("DespawnRecursive despawns the whole subtree and triggers OnDespawn for each entity", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		root, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entity, err := Spawn(world, &componentA{}, &Parent{Entity: root})
+		assert.NoError(err)
+		child, err := Spawn(world, &componentA{}, &Parent{Entity: entity})
+		assert.NoError(err)
+		grandchild, err := Spawn(world, &componentA{}, &Parent{Entity: child})
+		assert.NoError(err)
+		sibling, err := Spawn(world, &componentA{}, &Parent{Entity: root})
+		assert.NoError(err)
+
+		despawned := []EntityId{}
+		err = On[OnDespawn[componentA]](world, func(event OnDespawn[componentA]) {
+			despawned = append(despawned, event.Entity)
+		})
+		assert.NoError(err)
+
+		assert.NoError(DespawnRecursive(world, entity))
+		assert.Equal([]EntityId{grandchild, child, entity}, despawned)
+		assert.Equal(2, world.CountEntities())
+		assert.Equal([]EntityId{sibling}, getChildrenOf(world, root))
+	})
+
+	t.Run("Descendants and DespawnRecursive stop at cycles that got created by changing Parent in place", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entityA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entityB, err := Spawn(world, &Parent{Entity: entityA})
+		assert.NoError(err)
+		other, err := Spawn(world)
+		assert.NoError(err)
+
+		// the cycle check follows Parent, which no longer leads from entityB to entityA
+		parent, err := Get1[*Parent](world, entityB)
+		assert.NoError(err)
+		parent.Entity = other
+		assert.NoError(Insert(world, entityA, &Parent{Entity: entityB}))
+		assert.Equal([]EntityId{entityA}, getChildrenOf(world, entityB))
+
+		descendants, err := Descendants(world, entityA)
+		assert.NoError(err)
+		assert.Equal([]EntityId{entityB}, descendants)
+
+		assert.NoError(DespawnRecursive(world, entityA))
+		assert.Equal(1, world.CountEntities())
+	})
+
+	t.Run("DespawnRecursive returns an error if the entity does not exist", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		assert.ErrorIs(DespawnRecursive(world, nonExistingEntity), ErrEntityNotFound)
+	})
+}
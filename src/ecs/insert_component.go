@@ -2,6 +2,7 @@ package ecs
 
 import (
 	"fmt"
+	"reflect"
 	"slices"
 
 	"github.com/lucdrenth/murphecs/src/utils"
@@ -11,19 +12,23 @@ import (
 //
 // Can return the following errors:
 //   - Returns an ErrEntityNotFound error when the given entity does not exist
+//   - Returns an ErrEntityStale error when the given entity has been despawned
 //   - Returns an ErrComponentIsNil error when any of the given components is nil
+//   - Returns an ErrComponentIsRelation error when any of the given components is a [Relation]
 //   - Returns an ErrDuplicateComponent error when any of the given components are of the same type.
 //   - Returns an ErrComponentAlreadyPresent error if any of the components is already present while still inserting
 //     the components that are not yet present.
 //   - Returns an ErrInvalidComponentStorageCapacity if the component storage capacity, that is decided through World
 //     configs, is not valid
+//   - Returns an ErrParentNotFound error when the entity of a [Parent] component does not exist.
+//   - Returns an ErrHierarchyCycle error when the entity of a [Parent] component is the entity itself or one of its descendants.
 //   - Returns an ErrWorldIsLocked error while querying
 func Insert(world *World, entity EntityId, components ...AnyComponent) (resultErr error) {
 	if len(components) == 0 {
 		return nil
 	}
 
-	if world.isQuerying {
+	if world.isQuerying() {
 		// We can not allow this ecs operation while querying because archetype moves
 		// will mess with the query results.
 		return ErrWorldIsLocked
@@ -33,14 +38,21 @@ func Insert(world *World, entity EntityId, components ...AnyComponent) (resultEr
 		if component == nil {
 			return fmt.Errorf("%w: at position %d", ErrComponentIsNil, i+1)
 		}
+		if _, isRelation := component.(AnyRelation); isRelation {
+			return fmt.Errorf("%w: at position %d", ErrComponentIsRelation, i+1)
+		}
 	}
 
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return ErrEntityNotFound
-	}
+	return insert(world, entity, components, toComponentIds(components, world))
+}
 
-	componentIds := toComponentIds(components, world)
+// insert adds components to entity, where componentIds[i] is the id of components[i]. This allows inserting
+// relation pairs, of which the id can not be derived from the component itself.
+func insert(world *World, entity EntityId, components []AnyComponent, componentIds []ComponentId) (resultErr error) {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return err
+	}
 
 	// check for duplicates
 	duplicate, duplicateIndexA, duplicateIndexB := utils.GetFirstDuplicate(componentIds)
@@ -66,47 +78,41 @@ func Insert(world *World, entity EntityId, components ...AnyComponent) (resultEr
 		return resultErr
 	}
 
+	parentChange, err := prepareParentChange(world, entity, componentsToAdd)
+	if err != nil {
+		return err
+	}
+
 	// move archetype
 	newComponentIds := slices.Concat(componentIdsToAdd, oldArchetype.componentIds)
 	requiredComponents := getAllRequiredComponents(&newComponentIds, componentsToAdd, world)
 
-	newArchetype, err := world.archetypeStorage.getArchetype(world, newComponentIds)
+	newArchetype, err := world.archetypeStorage.getArchetypeAfterInsert(world, oldArchetype, componentIdsToAdd, newComponentIds)
 	if err != nil {
 		return err
 	}
 
-	var newRow uint
-	var movedComponent *movedComponent
-
 	for componentId, oldStorage := range oldArchetype.components {
 		rawComponent, err := oldStorage.getComponentPointer(entityData.row)
 		if err != nil {
 			return err
 		}
 
-		newRow, err = newArchetype.components[componentId].insertRaw(world, rawComponent)
+		_, err = newArchetype.components[componentId].insertRaw(world, rawComponent, oldStorage.ticks[entityData.row])
 		if err != nil {
 			return err
 		}
-
-		removeResult, err := oldStorage.remove(entityData.row)
-		if err != nil {
-			return err
-		}
-
-		movedComponent = removeResult
 	}
 
-	handleComponentStorageIndexMove(world, movedComponent, oldArchetype)
-	err = oldArchetype.removeEntity(entity)
+	err = removeEntityFromArchetype(world, oldArchetype, entityData.row)
 	if err != nil {
-		return fmt.Errorf("failed to remove entity from old archetype: %w", err)
+		return err
 	}
 
 	// insert new component
 	for i, component := range componentsToAdd {
 		storage := newArchetype.components[componentIdsToAdd[i]]
-		newRow, err = storage.insert(world, component)
+		_, err = storage.insert(world, component)
 		if err != nil {
 			resultErr = fmt.Errorf("failed to insert component %s in to component registry: %w", componentIdsToAdd[i].DebugString(), err)
 			continue
@@ -116,7 +122,7 @@ func Insert(world *World, entity EntityId, components ...AnyComponent) (resultEr
 	for _, component := range requiredComponents {
 		componentId := ComponentIdOf(component, world)
 		storage := newArchetype.components[componentId]
-		newRow, err = storage.insert(world, component)
+		_, err = storage.insert(world, component)
 		if err != nil {
 			resultErr = fmt.Errorf("failed to insert required component %s in to component registry: %w", componentId.DebugString(), err)
 			continue
@@ -124,13 +130,18 @@ func Insert(world *World, entity EntityId, components ...AnyComponent) (resultEr
 	}
 
 	entityData.archetype = newArchetype
-	entityData.row = newRow
-	world.archetypeStorage.entityIdToArchetype[entity] = newArchetype
-	newArchetype.entities = append(newArchetype.entities, entity)
+	entityData.row = newArchetype.addEntity(entity)
+
+	if err := parentChange.apply(world, entity); err != nil {
+		resultErr = err
+	}
+
+	// Observers may spawn entities, which invalidates entityData.
+	entityObservers := entityData.observers
 
 	world.observers.triggerSpawnObservers(world, componentIds, entity)
-	if entityData.observers != nil {
-		entityData.observers.triggerSpawnObservers(world, componentIds, entity)
+	if entityObservers != nil {
+		entityObservers.triggerSpawnObservers(world, componentIds, entity)
 	}
 
 	return resultErr
@@ -143,24 +154,34 @@ func Insert(world *World, entity EntityId, components ...AnyComponent) (resultEr
 //
 // Can return the following errors:
 //   - Returns an ErrEntityNotFound error when the given entity does not exist
+//   - Returns an ErrEntityStale error when the given entity has been despawned
+//   - Returns an ErrComponentIsRelation error when any of the given components is a [Relation]
 //   - Returns an ErrDuplicateComponent error when any of the given components are of the same type.
 //   - Returns an ErrInvalidComponentStorageCapacity if the component storage capacity, that is decided through World
 //     configs, is not valid
+//   - Returns an ErrParentNotFound error when the entity of a [Parent] component does not exist.
+//   - Returns an ErrHierarchyCycle error when the entity of a [Parent] component is the entity itself or one of its descendants.
 //   - Returns an ErrWorldIsLocked error while querying
 func InsertOrOverwrite(world *World, entity EntityId, components ...AnyComponent) (resultErr error) {
 	if len(components) == 0 {
 		return nil
 	}
 
-	if world.isQuerying {
+	if world.isQuerying() {
 		// We can not allow this ecs operation while querying because archetype moves
 		// will mess with the query results.
 		return ErrWorldIsLocked
 	}
 
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return ErrEntityNotFound
+	for i, component := range components {
+		if _, isRelation := component.(AnyRelation); isRelation {
+			return fmt.Errorf("%w: at position %d", ErrComponentIsRelation, i+1)
+		}
+	}
+
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return err
 	}
 
 	componentIds := toComponentIds(components, world)
@@ -173,13 +194,21 @@ func InsertOrOverwrite(world *World, entity EntityId, components ...AnyComponent
 
 	oldArchetype := entityData.archetype
 
+	parentChange, err := prepareParentChange(world, entity, components)
+	if err != nil {
+		return err
+	}
+
 	componentIdsToAdd := make([]ComponentId, 0, len(componentIds))
 	componentsToAdd := make([]AnyComponent, 0, len(components))
 	for i, componentId := range componentIds {
 		if oldArchetype.HasComponent(componentId) {
-			err := oldArchetype.components[componentId].set(components[i], entityData.row)
+			storage := oldArchetype.components[componentId]
+			err := storage.set(components[i], entityData.row)
 			if err != nil {
 				resultErr = err
+			} else {
+				storage.markChanged(entityData.row, world.ChangeTick())
 			}
 		} else {
 			componentIdsToAdd = append(componentIdsToAdd, componentId)
@@ -188,6 +217,9 @@ func InsertOrOverwrite(world *World, entity EntityId, components ...AnyComponent
 	}
 
 	if len(componentIdsToAdd) == 0 {
+		if err := parentChange.apply(world, entity); err != nil {
+			resultErr = err
+		}
 		return resultErr
 	}
 
@@ -195,43 +227,32 @@ func InsertOrOverwrite(world *World, entity EntityId, components ...AnyComponent
 	newComponentIds := slices.Concat(componentIdsToAdd, oldArchetype.componentIds)
 	requiredComponents := getAllRequiredComponents(&newComponentIds, componentsToAdd, world)
 
-	newArchetype, err := world.archetypeStorage.getArchetype(world, newComponentIds)
+	newArchetype, err := world.archetypeStorage.getArchetypeAfterInsert(world, oldArchetype, componentIdsToAdd, newComponentIds)
 	if err != nil {
 		return err
 	}
 
-	var newRow uint
-	var movedComponent *movedComponent
-
 	for componentId, oldStorage := range oldArchetype.components {
 		rawComponent, err := oldStorage.getComponentPointer(entityData.row)
 		if err != nil {
 			return err
 		}
 
-		newRow, err = newArchetype.components[componentId].insertRaw(world, rawComponent)
-		if err != nil {
-			return err
-		}
-
-		removeResult, err := oldStorage.remove(entityData.row)
+		_, err = newArchetype.components[componentId].insertRaw(world, rawComponent, oldStorage.ticks[entityData.row])
 		if err != nil {
 			return err
 		}
-
-		movedComponent = removeResult
 	}
 
-	handleComponentStorageIndexMove(world, movedComponent, oldArchetype)
-	err = oldArchetype.removeEntity(entity)
+	err = removeEntityFromArchetype(world, oldArchetype, entityData.row)
 	if err != nil {
-		return fmt.Errorf("failed to remove entity from old archetype: %w", err)
+		return err
 	}
 
 	// insert new component
 	for i, component := range componentsToAdd {
 		storage := newArchetype.components[componentIdsToAdd[i]]
-		newRow, err = storage.insert(world, component)
+		_, err = storage.insert(world, component)
 		if err != nil {
 			resultErr = fmt.Errorf("failed to insert component %s in to component registry: %w", componentIdsToAdd[i].DebugString(), err)
 			continue
@@ -241,7 +262,7 @@ func InsertOrOverwrite(world *World, entity EntityId, components ...AnyComponent
 	for _, component := range requiredComponents {
 		componentId := ComponentIdOf(component, world)
 		storage := newArchetype.components[componentId]
-		newRow, err = storage.insert(world, component)
+		_, err = storage.insert(world, component)
 		if err != nil {
 			resultErr = fmt.Errorf("failed to insert required component %s in to component registry: %w", componentId.DebugString(), err)
 			continue
@@ -249,14 +270,83 @@ func InsertOrOverwrite(world *World, entity EntityId, components ...AnyComponent
 	}
 
 	entityData.archetype = newArchetype
-	entityData.row = newRow
-	world.archetypeStorage.entityIdToArchetype[entity] = newArchetype
-	newArchetype.entities = append(newArchetype.entities, entity)
+	entityData.row = newArchetype.addEntity(entity)
+
+	if err := parentChange.apply(world, entity); err != nil {
+		resultErr = err
+	}
+
+	// Observers may spawn entities, which invalidates entityData.
+	entityObservers := entityData.observers
 
 	world.observers.triggerSpawnObservers(world, componentIds, entity)
-	if entityData.observers != nil {
-		entityData.observers.triggerSpawnObservers(world, componentIds, entity)
+	if entityObservers != nil {
+		entityObservers.triggerSpawnObservers(world, componentIds, entity)
 	}
 
 	return resultErr
 }
+
+// InsertValue adds the given components and all their required components (that the entity does not yet have) to
+// the given entity, just like [Insert]. Each value is a component, such as Position, or a pointer to a component,
+// such as *Position. Use this when the component types are only known at runtime.
+//
+// Can return the following errors:
+//   - Returns an ErrComponentIsNil error when any of the given values is not valid or is a nil pointer
+//   - Returns an ErrComponentTypeNotValid error when the type of any of the given values is not a component
+//   - Any error that [Insert] returns
+func InsertValue(world *World, entity EntityId, values ...reflect.Value) error {
+	components, err := componentsFromValues(values)
+	if err != nil {
+		return err
+	}
+
+	return Insert(world, entity, components...)
+}
+
+// InsertOrOverwriteValue adds the given components to the given entity, overwriting the components that the
+// entity already has, just like [InsertOrOverwrite]. Each value is a component, such as Position, or a pointer to
+// a component, such as *Position. Use this when the component types are only known at runtime.
+//
+// Can return the following errors:
+//   - Returns an ErrComponentIsNil error when any of the given values is not valid or is a nil pointer
+//   - Returns an ErrComponentTypeNotValid error when the type of any of the given values is not a component
+//   - Any error that [InsertOrOverwrite] returns
+func InsertOrOverwriteValue(world *World, entity EntityId, values ...reflect.Value) error {
+	components, err := componentsFromValues(values)
+	if err != nil {
+		return err
+	}
+
+	return InsertOrOverwrite(world, entity, components...)
+}
+
+// componentsFromValues converts values to pointers to components, so that they can be inserted. Values that are
+// not pointers are copied.
+func componentsFromValues(values []reflect.Value) ([]AnyComponent, error) {
+	components := make([]AnyComponent, len(values))
+
+	for i, value := range values {
+		if !value.IsValid() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+			return nil, fmt.Errorf("%w: at position %d", ErrComponentIsNil, i+1)
+		}
+
+		if value.Kind() != reflect.Pointer {
+			pointer := reflect.New(value.Type())
+			pointer.Elem().Set(value)
+			value = pointer
+		}
+
+		if value.Type().Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w: %s at position %d", ErrComponentTypeNotValid, value.Type().Elem(), i+1)
+		}
+
+		component, isComponent := value.Interface().(AnyComponent)
+		if !isComponent {
+			return nil, fmt.Errorf("%w: %s at position %d", ErrComponentTypeNotValid, value.Type().Elem(), i+1)
+		}
+		components[i] = component
+	}
+
+	return components, nil
+}
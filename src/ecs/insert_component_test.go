@@ -1,6 +1,7 @@
 package ecs
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -263,3 +264,73 @@ func TestInsertOrOverwrite(t *testing.T) {
 		assert.Equal(4, world.CountComponents())
 	})
 }
+
+func TestInsertValue(t *testing.T) {
+	type componentA struct {
+		Component
+		Value int
+	}
+	type componentB struct{ Component }
+	type notAComponent struct{ Value int }
+
+	t.Run("inserts components and pointers to components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world)
+		assert.NoError(err)
+
+		err = InsertValue(world, entity, reflect.ValueOf(componentA{Value: 1}), reflect.ValueOf(&componentB{}))
+		assert.NoError(err)
+
+		a, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(1, a.Value)
+		hasB, err := HasComponent[componentB](world, entity)
+		assert.NoError(err)
+		assert.True(hasB)
+	})
+
+	t.Run("inserts the required components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world)
+		assert.NoError(err)
+
+		assert.NoError(InsertValue(world, entity, reflect.ValueOf(testInsertComponentB{})))
+
+		hasA, err := HasComponent[testInsertComponentA](world, entity)
+		assert.NoError(err)
+		assert.True(hasA)
+	})
+
+	t.Run("InsertOrOverwriteValue overwrites existing components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{Value: 1})
+		assert.NoError(err)
+
+		assert.ErrorIs(InsertValue(world, entity, reflect.ValueOf(componentA{Value: 2})), ErrComponentAlreadyPresent)
+		assert.NoError(InsertOrOverwriteValue(world, entity, reflect.ValueOf(componentA{Value: 3})))
+
+		a, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(3, a.Value)
+	})
+
+	t.Run("returns an error for values that are not components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world)
+		assert.NoError(err)
+
+		assert.ErrorIs(InsertValue(world, entity, reflect.Value{}), ErrComponentIsNil)
+		assert.ErrorIs(InsertValue(world, entity, reflect.ValueOf((*componentA)(nil))), ErrComponentIsNil)
+		assert.ErrorIs(InsertValue(world, entity, reflect.ValueOf(notAComponent{})), ErrComponentTypeNotValid)
+		assert.ErrorIs(InsertValue(world, entity, reflect.ValueOf(5)), ErrComponentTypeNotValid)
+		assert.ErrorIs(InsertOrOverwriteValue(world, entity, reflect.ValueOf(&notAComponent{})), ErrComponentTypeNotValid)
+
+		componentIds, err := ComponentIdsOfEntity(world, entity)
+		assert.NoError(err)
+		assert.Empty(componentIds)
+	})
+}
@@ -0,0 +1,72 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// Mut gives access to a component, and marks the component as changed when it gets modified through Mut.
+// This makes the modification visible to the [Changed] query filter.
+//
+// Mut is only valid until the next structural change of the world, such as spawning or despawning entities.
+//
+// WARNING: Do not store Mut
+type Mut[T AnyComponent] struct {
+	storage *componentStorage
+	row     uint
+	tick    uint32
+}
+
+// GetMut returns a Mut for the component T of entity. The component is marked as changed at the current
+// change tick of the world (see [World.ChangeTick]) when it is modified through the Mut.
+//
+// Can return the following errors:
+//   - ErrEntityNotFound error if the entity is not found.
+//   - ErrEntityStale error if the entity has been despawned.
+//   - ErrComponentNotFound error if the entity does not have the component.
+//   - ErrMutPointerComponent error if T is a pointer.
+func GetMut[T AnyComponent](world *World, entity EntityId) (Mut[T], error) {
+	if reflect.TypeFor[T]().Kind() == reflect.Pointer {
+		return Mut[T]{}, fmt.Errorf("%w: %s", ErrMutPointerComponent, reflect.TypeFor[T]().String())
+	}
+
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return Mut[T]{}, err
+	}
+
+	storage, componentExists := entityData.archetype.components[ComponentIdFor[T](world)]
+	if !componentExists {
+		return Mut[T]{}, ErrComponentNotFound
+	}
+
+	return Mut[T]{
+		storage: storage,
+		row:     entityData.row,
+		tick:    world.ChangeTick(),
+	}, nil
+}
+
+func (m Mut[T]) pointer() *T {
+	return (*T)(unsafe.Add(m.storage.pointerToStart, uintptr(m.row)*m.storage.componentSize))
+}
+
+// Get returns a copy of the component, without marking it as changed.
+func (m Mut[T]) Get() T {
+	return *m.pointer()
+}
+
+// Set overwrites the component and marks it as changed.
+func (m Mut[T]) Set(component T) {
+	*m.pointer() = component
+	m.storage.markChanged(m.row, m.tick)
+}
+
+// Ptr marks the component as changed and returns a pointer to it, which can be used to modify it.
+//
+// WARNING: Do not store the component pointer
+func (m Mut[T]) Ptr() *T {
+	m.storage.markChanged(m.row, m.tick)
+	return m.pointer()
+}
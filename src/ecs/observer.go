@@ -84,9 +84,9 @@ func Trigger[O AnyObserver](world *World, observed O) {
 
 // TriggerEntity triggers all registered observers for the given observer on a specific entity
 func TriggerEntity[O AnyObserver](world *World, entity EntityId, observed O) error {
-	entityData, exists := world.entities[entity]
-	if !exists {
-		return ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return err
 	}
 	if entityData.observers == nil {
 		return nil
@@ -100,9 +100,9 @@ func TriggerEntity[O AnyObserver](world *World, entity EntityId, observed O) err
 // can optionally take O as a parameter, which will be set to the triggered observer value before
 // running.
 func Observe[O AnyObserver](world *World, entity EntityId, action System) error {
-	entityData, ok := world.entities[entity]
-	if !ok {
-		return ErrEntityNotFound
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return err
 	}
 
 	if entityData.observers == nil {
@@ -59,31 +59,26 @@ func TestGlobalObserver(t *testing.T) {
 		assert := assert.New(t)
 		world := NewDefaultWorld()
 
-		nrObserved := 0
-		var expectedEntityId EntityId
+		observedEntityIds := []EntityId{}
 
 		assert.NoError(On[OnSpawn[myComponent1]](world, func(world *World, observed OnSpawn[myComponent1]) {
-			nrObserved++
-			assert.Equal(expectedEntityId, observed.Entity)
+			observedEntityIds = append(observedEntityIds, observed.Entity)
 		}))
 
 		assert.NoError(On[OnDespawn[myComponent1]](world, func(world *World, observed OnDespawn[myComponent1]) {
 			assert.FailNow("did not expect OnDespawn to trigger")
 		}))
 
-		expectedEntityId = 1
-		_, err := Spawn(world, myComponent1{}) // triggers
+		id1, err := Spawn(world, myComponent1{}) // triggers
 		assert.NoError(err)
 		_, err = Spawn(world, myComponent2{}) // does not trigger
 		assert.NoError(err)
-		expectedEntityId = 3
-		_, err = Spawn(world, myComponent1{}, myComponent2{}) // triggers
+		id3, err := Spawn(world, myComponent1{}, myComponent2{}) // triggers
 		assert.NoError(err)
-		expectedEntityId = 4
-		_, err = Spawn(world, myComponent2{}, myComponent1{}) // triggers
+		id4, err := Spawn(world, myComponent2{}, myComponent1{}) // triggers
 		assert.NoError(err)
 
-		assert.Equal(3, nrObserved)
+		assert.Equal([]EntityId{id1, id3, id4}, observedEntityIds)
 	})
 
 	t.Run("OnDespawn", func(t *testing.T) {
@@ -134,7 +129,7 @@ func TestEntityObserver(t *testing.T) {
 		assert := assert.New(t)
 		world := NewDefaultWorld()
 
-		err := Observe[observer1](world, EntityId(5), func(world *World, o observer1) {})
+		err := Observe[observer1](world, EntityId{}, func(world *World, o observer1) {})
 		assert.ErrorIs(err, ErrEntityNotFound)
 	})
 
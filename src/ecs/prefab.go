@@ -0,0 +1,192 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Prefab is a named template for spawning entities. Register it with [RegisterPrefab] and spawn it with
+// [SpawnPrefab].
+//
+// Components are copied shallowly when spawning, so reference types such as slices and maps are shared between
+// the entities and the prefab.
+type Prefab struct {
+	Name string
+
+	// Extends is the name of the prefab that this prefab inherits from. The components of this prefab replace the
+	// components of the same type of the inherited prefab, and the children of this prefab are spawned after the
+	// children of the inherited prefab. Empty if the prefab does not inherit from another prefab.
+	Extends string
+
+	// Components are the default values of the components of the entity. Required components that are not in
+	// Components are added as usual, see [AnyComponent.RequiredComponents].
+	Components []AnyComponent
+
+	// Children are spawned as children of the entity, see [Parent].
+	Children []PrefabChild
+}
+
+// PrefabChild is a child of a [Prefab].
+type PrefabChild struct {
+	// Prefab is the name of the prefab of the child.
+	Prefab string
+
+	// Overrides replace the components of the same type of the prefab of the child, or are added to it.
+	Overrides []AnyComponent
+}
+
+// prefabRegistry stores the prefabs of a world by their name.
+type prefabRegistry struct {
+	prefabs map[string]Prefab
+}
+
+func newPrefabRegistry() prefabRegistry {
+	return prefabRegistry{
+		prefabs: map[string]Prefab{},
+	}
+}
+
+// RegisterPrefab registers prefab so that it can be spawned with [SpawnPrefab]. The prefabs that the prefab extends
+// and the prefabs of its children do not have to be registered yet.
+//
+// Can return the following errors:
+//   - ErrPrefabNameEmpty error if the prefab does not have a name.
+//   - ErrPrefabAlreadyExists error if a prefab with the same name is already registered.
+//   - ErrComponentIsNil error if any of the components of the prefab is nil.
+func RegisterPrefab(world *World, prefab Prefab) error {
+	if prefab.Name == "" {
+		return ErrPrefabNameEmpty
+	}
+
+	if _, exists := world.prefabs.prefabs[prefab.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrPrefabAlreadyExists, prefab.Name)
+	}
+
+	for i, component := range prefab.Components {
+		if component == nil {
+			return fmt.Errorf("%w: at position %d", ErrComponentIsNil, i+1)
+		}
+	}
+
+	prefab.Components = slices.Clone(prefab.Components)
+	prefab.Children = slices.Clone(prefab.Children)
+	world.prefabs.prefabs[prefab.Name] = prefab
+	return nil
+}
+
+// SpawnPrefab spawns an entity from the prefab with the given name, together with the children of the prefab.
+// The given overrides replace the components of the same type of the prefab, or are added to it.
+//
+// If any of the children fails to spawn, the entity and the children that did get spawned are despawned again.
+//
+// Can return the following errors:
+//   - ErrPrefabNotFound error if the prefab, the prefab that it extends or the prefab of any of its children is
+//     not registered.
+//   - ErrPrefabCycle error if the prefab extends itself or contains itself as a (grand)child, directly or indirectly.
+//   - Any error that [Spawn] returns.
+func SpawnPrefab(world *World, name string, overrides ...AnyComponent) (EntityId, error) {
+	return spawnPrefab(world, name, overrides, []string{})
+}
+
+// spawnPrefab spawns the prefab with the given name. spawning contains the names of the prefabs of which a
+// descendant is being spawned, to detect cycles.
+func spawnPrefab(world *World, name string, overrides []AnyComponent, spawning []string) (EntityId, error) {
+	if slices.Contains(spawning, name) {
+		return nonExistingEntity, fmt.Errorf("%w: %s", ErrPrefabCycle, name)
+	}
+	spawning = append(spawning, name)
+
+	components, children, err := world.prefabs.resolve(name)
+	if err != nil {
+		return nonExistingEntity, err
+	}
+
+	entity, err := Spawn(world, mergeComponents(components, overrides)...)
+	if err != nil {
+		return nonExistingEntity, fmt.Errorf("failed to spawn prefab %s: %w", name, err)
+	}
+
+	for _, child := range children {
+		childOverrides := mergeComponents(child.Overrides, []AnyComponent{&Parent{Entity: entity}})
+
+		_, err := spawnPrefab(world, child.Prefab, childOverrides, spawning)
+		if err != nil {
+			return nonExistingEntity, errors.Join(
+				fmt.Errorf("failed to spawn child of prefab %s: %w", name, err),
+				DespawnRecursive(world, entity),
+			)
+		}
+	}
+
+	return entity, nil
+}
+
+// resolve returns the components and the children of the prefab with the given name, including the ones that it
+// inherits.
+//
+// Can return the following errors:
+//   - ErrPrefabNotFound error if the prefab or a prefab that it extends is not registered.
+//   - ErrPrefabCycle error if the prefab extends itself, directly or indirectly.
+func (registry *prefabRegistry) resolve(name string) ([]AnyComponent, []PrefabChild, error) {
+	// collect the prefab and the prefabs that it extends, from the prefab itself to the root
+	chain := []Prefab{}
+	for current := name; current != ""; {
+		prefab, exists := registry.prefabs[current]
+		if !exists {
+			return nil, nil, fmt.Errorf("%w: %s", ErrPrefabNotFound, current)
+		}
+
+		if slices.ContainsFunc(chain, func(p Prefab) bool { return p.Name == current }) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrPrefabCycle, current)
+		}
+
+		chain = append(chain, prefab)
+		current = prefab.Extends
+	}
+
+	components := []AnyComponent{}
+	children := []PrefabChild{}
+	for _, prefab := range slices.Backward(chain) {
+		components = mergeComponents(components, prefab.Components)
+		children = append(children, prefab.Children...)
+	}
+
+	return components, children, nil
+}
+
+// mergeComponents returns the components of base in which the components of the same type as any of overrides are
+// replaced, followed by the overrides that have a type that is not in base.
+func mergeComponents(base []AnyComponent, overrides []AnyComponent) []AnyComponent {
+	result := slices.Clone(base)
+
+	for _, override := range overrides {
+		index := slices.IndexFunc(result, func(component AnyComponent) bool {
+			return componentType(component) == componentType(override)
+		})
+
+		if index == -1 {
+			result = append(result, override)
+		} else {
+			result[index] = override
+		}
+	}
+
+	return result
+}
+
+// componentType returns the type of component, without the pointer if component is a pointer. Returns nil if
+// component is nil.
+func componentType(component AnyComponent) reflect.Type {
+	if component == nil {
+		return nil
+	}
+
+	result := reflect.TypeOf(component)
+	if result.Kind() == reflect.Pointer {
+		result = result.Elem()
+	}
+
+	return result
+}
@@ -0,0 +1,149 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefab(t *testing.T) {
+	type health struct {
+		Component
+		value int
+	}
+	type damage struct {
+		Component
+		value int
+	}
+	type weapon struct{ Component }
+	type elite struct{ Component }
+
+	t.Run("spawns an entity with the components of the prefab", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		assert.NoError(RegisterPrefab(world, Prefab{
+			Name:       "orc",
+			Components: []AnyComponent{&health{value: 100}, damage{value: 10}},
+		}))
+
+		entity, err := SpawnPrefab(world, "orc")
+		assert.NoError(err)
+
+		h, d, err := Get2[health, damage](world, entity)
+		assert.NoError(err)
+		assert.Equal(100, h.value)
+		assert.Equal(10, d.value)
+	})
+
+	t.Run("overrides replace or add components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		assert.NoError(RegisterPrefab(world, Prefab{
+			Name:       "orc",
+			Components: []AnyComponent{&health{value: 100}, &damage{value: 10}},
+		}))
+
+		entity, err := SpawnPrefab(world, "orc", &health{value: 50}, &elite{})
+		assert.NoError(err)
+
+		h, d, _, err := Get3[health, damage, elite](world, entity)
+		assert.NoError(err)
+		assert.Equal(50, h.value)
+		assert.Equal(10, d.value)
+
+		entity, err = SpawnPrefab(world, "orc")
+		assert.NoError(err)
+		h, err = Get1[health](world, entity)
+		assert.NoError(err)
+		assert.Equal(100, h.value)
+	})
+
+	t.Run("prefabs inherit the components and children of the prefab that they extend", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		// registration order does not matter
+		assert.NoError(RegisterPrefab(world, Prefab{
+			Name:       "elite orc",
+			Extends:    "orc",
+			Components: []AnyComponent{&health{value: 200}, &elite{}},
+			Children:   []PrefabChild{{Prefab: "weapon", Overrides: []AnyComponent{&damage{value: 30}}}},
+		}))
+		assert.NoError(RegisterPrefab(world, Prefab{
+			Name:       "orc",
+			Components: []AnyComponent{&health{value: 100}, &damage{value: 10}},
+			Children:   []PrefabChild{{Prefab: "weapon"}},
+		}))
+		assert.NoError(RegisterPrefab(world, Prefab{
+			Name:       "weapon",
+			Components: []AnyComponent{&weapon{}, &damage{value: 5}},
+		}))
+
+		entity, err := SpawnPrefab(world, "elite orc")
+		assert.NoError(err)
+
+		h, d, _, err := Get3[health, damage, elite](world, entity)
+		assert.NoError(err)
+		assert.Equal(200, h.value)
+		assert.Equal(10, d.value)
+
+		descendants, err := Descendants(world, entity)
+		assert.NoError(err)
+		assert.Len(descendants, 2)
+
+		damages := []int{}
+		for _, descendant := range descendants {
+			d, err := Get1[damage](world, descendant)
+			assert.NoError(err)
+			damages = append(damages, d.value)
+		}
+		assert.Equal([]int{5, 30}, damages)
+	})
+
+	t.Run("returns an error for unknown prefabs and rolls back spawned entities", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := SpawnPrefab(world, "orc")
+		assert.ErrorIs(err, ErrPrefabNotFound)
+
+		assert.NoError(RegisterPrefab(world, Prefab{
+			Name:       "orc",
+			Components: []AnyComponent{&health{}},
+			Children:   []PrefabChild{{Prefab: "weapon"}, {Prefab: "shield"}},
+		}))
+		assert.NoError(RegisterPrefab(world, Prefab{Name: "weapon", Components: []AnyComponent{&weapon{}}}))
+
+		_, err = SpawnPrefab(world, "orc")
+		assert.ErrorIs(err, ErrPrefabNotFound)
+		assert.Equal(0, world.CountEntities())
+	})
+
+	t.Run("returns an error for cycles", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		assert.NoError(RegisterPrefab(world, Prefab{Name: "a", Extends: "b"}))
+		assert.NoError(RegisterPrefab(world, Prefab{Name: "b", Extends: "a"}))
+		assert.NoError(RegisterPrefab(world, Prefab{Name: "c", Children: []PrefabChild{{Prefab: "d"}}}))
+		assert.NoError(RegisterPrefab(world, Prefab{Name: "d", Children: []PrefabChild{{Prefab: "c"}}}))
+
+		_, err := SpawnPrefab(world, "a")
+		assert.ErrorIs(err, ErrPrefabCycle)
+		_, err = SpawnPrefab(world, "c")
+		assert.ErrorIs(err, ErrPrefabCycle)
+		assert.Equal(0, world.CountEntities())
+	})
+
+	t.Run("returns an error for invalid prefabs", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		assert.ErrorIs(RegisterPrefab(world, Prefab{}), ErrPrefabNameEmpty)
+		assert.ErrorIs(RegisterPrefab(world, Prefab{Name: "a", Components: []AnyComponent{nil}}), ErrComponentIsNil)
+		assert.NoError(RegisterPrefab(world, Prefab{Name: "a"}))
+		assert.ErrorIs(RegisterPrefab(world, Prefab{Name: "a"}), ErrPrefabAlreadyExists)
+	})
+}
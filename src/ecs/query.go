@@ -36,6 +36,8 @@ type Query interface {
 	TargetWorld() *WorldId
 
 	getOptions() *CombinedQueryOptions
+	getComponentInfos() []queryComponentInfo
+	setChangeTicks(lastRun uint32, thisRun uint32)
 }
 
 type queryComponentInfo struct {
@@ -51,14 +53,35 @@ func queryComponentInfoFor[T AnyComponent](world *World) queryComponentInfo {
 }
 
 type queryOptions struct {
-	options    CombinedQueryOptions
-	components []ComponentId
+	options        CombinedQueryOptions
+	components     []ComponentId
+	componentInfos []queryComponentInfo
+	archetypeCache queryArchetypeCache
+	changeTicks    queryChangeTicks
+
+	hasPointerComponents bool         // whether any of the components is queried as a pointer
+	parallelBatches      []queryBatch // reused between calls to ParIter
+}
+
+// setComponents sets the components of the query, in the order of the type parameters of the query.
+func (o *queryOptions) setComponents(componentInfos ...queryComponentInfo) {
+	o.componentInfos = componentInfos
+	o.components = make([]ComponentId, len(componentInfos))
+	o.hasPointerComponents = false
+	for i := range componentInfos {
+		o.components[i] = componentInfos[i].id
+		o.hasPointerComponents = o.hasPointerComponents || componentInfos[i].isPointer
+	}
 }
 
 func (o *queryOptions) getOptions() *CombinedQueryOptions {
 	return &o.options
 }
 
+func (o *queryOptions) getComponentInfos() []queryComponentInfo {
+	return o.componentInfos
+}
+
 func (o *queryOptions) IsLazy() bool {
 	return o.options.isLazy
 }
@@ -547,13 +570,26 @@ func (q *Query0[QueryOptions]) Exec(world *World) error {
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
+
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		if !hasChangeFilter {
+			q.entityIds = append(q.entityIds, match.archetype.entities...)
 			continue
 		}
 
-		q.entityIds = append(q.entityIds, archetype.entities...)
+		for row, entity := range match.archetype.entities {
+			if q.options.rowMeetsCriteria(match.archetype, uint(row), q.changeTicks) {
+				q.entityIds = append(q.entityIds, entity)
+			}
+		}
 	}
 
 	return nil
@@ -563,27 +599,35 @@ func (q *Query1[ComponentA, QueryOptions]) Exec(world *World) (err error) {
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			q.componentsA = append(q.componentsA, a)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -594,33 +638,34 @@ func (q *Query2[ComponentA, ComponentB, QueryOptions]) Exec(world *World) (err e
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -628,6 +673,9 @@ func (q *Query2[ComponentA, ComponentB, QueryOptions]) Exec(world *World) (err e
 
 			q.componentsA = append(q.componentsA, a)
 			q.componentsB = append(q.componentsB, b)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -638,45 +686,42 @@ func (q *Query3[ComponentA, ComponentB, ComponentC, QueryOptions]) Exec(world *W
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c ComponentC
-			if fetchC {
-				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -685,6 +730,9 @@ func (q *Query3[ComponentA, ComponentB, ComponentC, QueryOptions]) Exec(world *W
 			q.componentsA = append(q.componentsA, a)
 			q.componentsB = append(q.componentsB, b)
 			q.componentsC = append(q.componentsC, c)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -695,57 +743,50 @@ func (q *Query4[ComponentA, ComponentB, ComponentC, ComponentD, QueryOptions]) E
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c ComponentC
-			if fetchC {
-				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d ComponentD
-			if fetchD {
-				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -755,6 +796,9 @@ func (q *Query4[ComponentA, ComponentB, ComponentC, ComponentD, QueryOptions]) E
 			q.componentsB = append(q.componentsB, b)
 			q.componentsC = append(q.componentsC, c)
 			q.componentsD = append(q.componentsD, d)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -765,69 +809,58 @@ func (q *Query5[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Quer
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c ComponentC
-			if fetchC {
-				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d ComponentD
-			if fetchD {
-				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e ComponentE
-			if fetchE {
-				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -838,6 +871,9 @@ func (q *Query5[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Quer
 			q.componentsC = append(q.componentsC, c)
 			q.componentsD = append(q.componentsD, d)
 			q.componentsE = append(q.componentsE, e)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -848,81 +884,66 @@ func (q *Query6[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Comp
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c ComponentC
-			if fetchC {
-				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d ComponentD
-			if fetchD {
-				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e ComponentE
-			if fetchE {
-				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f ComponentF
-			if fetchF {
-				f, err = fetchComponentForQueryResult[ComponentF](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[ComponentF](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -934,6 +955,9 @@ func (q *Query6[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Comp
 			q.componentsD = append(q.componentsD, d)
 			q.componentsE = append(q.componentsE, e)
 			q.componentsF = append(q.componentsF, f)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -944,93 +968,74 @@ func (q *Query7[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Comp
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c ComponentC
-			if fetchC {
-				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d ComponentD
-			if fetchD {
-				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e ComponentE
-			if fetchE {
-				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f ComponentF
-			if fetchF {
-				f, err = fetchComponentForQueryResult[ComponentF](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[ComponentF](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g ComponentG
-			if fetchG {
-				g, err = fetchComponentForQueryResult[ComponentG](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[ComponentG](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1043,6 +1048,9 @@ func (q *Query7[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Comp
 			q.componentsE = append(q.componentsE, e)
 			q.componentsF = append(q.componentsF, f)
 			q.componentsG = append(q.componentsG, g)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1053,105 +1061,82 @@ func (q *Query8[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Comp
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a ComponentA
-			if fetchA {
-				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[ComponentA](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b ComponentB
-			if fetchB {
-				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[ComponentB](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c ComponentC
-			if fetchC {
-				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[ComponentC](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d ComponentD
-			if fetchD {
-				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[ComponentD](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e ComponentE
-			if fetchE {
-				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[ComponentE](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f ComponentF
-			if fetchF {
-				f, err = fetchComponentForQueryResult[ComponentF](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[ComponentF](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g ComponentG
-			if fetchG {
-				g, err = fetchComponentForQueryResult[ComponentG](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[ComponentG](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h ComponentH
-			if fetchH {
-				h, err = fetchComponentForQueryResult[ComponentH](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[ComponentH](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1165,6 +1150,9 @@ func (q *Query8[ComponentA, ComponentB, ComponentC, ComponentD, ComponentE, Comp
 			q.componentsF = append(q.componentsF, f)
 			q.componentsG = append(q.componentsG, g)
 			q.componentsH = append(q.componentsH, h)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1176,117 +1164,90 @@ func (q *Query9[A, B, C, D, E, F, G, H, I, Options]) Exec(world *World) (err err
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1301,6 +1262,9 @@ func (q *Query9[A, B, C, D, E, F, G, H, I, Options]) Exec(world *World) (err err
 			q.componentsG = append(q.componentsG, g)
 			q.componentsH = append(q.componentsH, h)
 			q.componentsI = append(q.componentsI, i)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1312,129 +1276,98 @@ func (q *Query10[A, B, C, D, E, F, G, H, I, J, Options]) Exec(world *World) (err
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1450,6 +1383,9 @@ func (q *Query10[A, B, C, D, E, F, G, H, I, J, Options]) Exec(world *World) (err
 			q.componentsH = append(q.componentsH, h)
 			q.componentsI = append(q.componentsI, i)
 			q.componentsJ = append(q.componentsJ, j)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1461,141 +1397,106 @@ func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, Options]) Exec(world *World) (
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchK, skip := shouldHandleQueryComponent(q.componentInfoK.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var k K
-			if fetchK {
-				k, err = fetchComponentForQueryResult[K](q.componentInfoK, world.entities[entity].row, archetype)
+			if match.fetch[10] {
+				k, err = fetchComponentForQueryResult[K](q.componentInfoK, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1612,6 +1513,9 @@ func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, Options]) Exec(world *World) (
 			q.componentsI = append(q.componentsI, i)
 			q.componentsJ = append(q.componentsJ, j)
 			q.componentsK = append(q.componentsK, k)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1623,153 +1527,114 @@ func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, Options]) Exec(world *World
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchK, skip := shouldHandleQueryComponent(q.componentInfoK.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchL, skip := shouldHandleQueryComponent(q.componentInfoL.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var k K
-			if fetchK {
-				k, err = fetchComponentForQueryResult[K](q.componentInfoK, world.entities[entity].row, archetype)
+			if match.fetch[10] {
+				k, err = fetchComponentForQueryResult[K](q.componentInfoK, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var l L
-			if fetchL {
-				l, err = fetchComponentForQueryResult[L](q.componentInfoL, world.entities[entity].row, archetype)
+			if match.fetch[11] {
+				l, err = fetchComponentForQueryResult[L](q.componentInfoL, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1787,6 +1652,9 @@ func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, Options]) Exec(world *World
 			q.componentsJ = append(q.componentsJ, j)
 			q.componentsK = append(q.componentsK, k)
 			q.componentsL = append(q.componentsL, l)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1798,165 +1666,122 @@ func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, Options]) Exec(world *Wo
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchK, skip := shouldHandleQueryComponent(q.componentInfoK.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchL, skip := shouldHandleQueryComponent(q.componentInfoL.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchM, skip := shouldHandleQueryComponent(q.componentInfoM.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var k K
-			if fetchK {
-				k, err = fetchComponentForQueryResult[K](q.componentInfoK, world.entities[entity].row, archetype)
+			if match.fetch[10] {
+				k, err = fetchComponentForQueryResult[K](q.componentInfoK, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var l L
-			if fetchL {
-				l, err = fetchComponentForQueryResult[L](q.componentInfoL, world.entities[entity].row, archetype)
+			if match.fetch[11] {
+				l, err = fetchComponentForQueryResult[L](q.componentInfoL, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var m M
-			if fetchM {
-				m, err = fetchComponentForQueryResult[M](q.componentInfoM, world.entities[entity].row, archetype)
+			if match.fetch[12] {
+				m, err = fetchComponentForQueryResult[M](q.componentInfoM, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -1975,6 +1800,9 @@ func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, Options]) Exec(world *Wo
 			q.componentsK = append(q.componentsK, k)
 			q.componentsL = append(q.componentsL, l)
 			q.componentsM = append(q.componentsM, m)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -1986,177 +1814,130 @@ func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, Options]) Exec(world
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchK, skip := shouldHandleQueryComponent(q.componentInfoK.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchL, skip := shouldHandleQueryComponent(q.componentInfoL.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchM, skip := shouldHandleQueryComponent(q.componentInfoM.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchN, skip := shouldHandleQueryComponent(q.componentInfoN.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var k K
-			if fetchK {
-				k, err = fetchComponentForQueryResult[K](q.componentInfoK, world.entities[entity].row, archetype)
+			if match.fetch[10] {
+				k, err = fetchComponentForQueryResult[K](q.componentInfoK, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var l L
-			if fetchL {
-				l, err = fetchComponentForQueryResult[L](q.componentInfoL, world.entities[entity].row, archetype)
+			if match.fetch[11] {
+				l, err = fetchComponentForQueryResult[L](q.componentInfoL, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var m M
-			if fetchM {
-				m, err = fetchComponentForQueryResult[M](q.componentInfoM, world.entities[entity].row, archetype)
+			if match.fetch[12] {
+				m, err = fetchComponentForQueryResult[M](q.componentInfoM, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var n N
-			if fetchN {
-				n, err = fetchComponentForQueryResult[N](q.componentInfoN, world.entities[entity].row, archetype)
+			if match.fetch[13] {
+				n, err = fetchComponentForQueryResult[N](q.componentInfoN, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -2176,6 +1957,9 @@ func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, Options]) Exec(world
 			q.componentsL = append(q.componentsL, l)
 			q.componentsM = append(q.componentsM, m)
 			q.componentsN = append(q.componentsN, n)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -2187,189 +1971,138 @@ func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, Options]) Exec(wor
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchK, skip := shouldHandleQueryComponent(q.componentInfoK.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchL, skip := shouldHandleQueryComponent(q.componentInfoL.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchM, skip := shouldHandleQueryComponent(q.componentInfoM.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchN, skip := shouldHandleQueryComponent(q.componentInfoN.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchO, skip := shouldHandleQueryComponent(q.componentInfoO.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var k K
-			if fetchK {
-				k, err = fetchComponentForQueryResult[K](q.componentInfoK, world.entities[entity].row, archetype)
+			if match.fetch[10] {
+				k, err = fetchComponentForQueryResult[K](q.componentInfoK, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var l L
-			if fetchL {
-				l, err = fetchComponentForQueryResult[L](q.componentInfoL, world.entities[entity].row, archetype)
+			if match.fetch[11] {
+				l, err = fetchComponentForQueryResult[L](q.componentInfoL, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var m M
-			if fetchM {
-				m, err = fetchComponentForQueryResult[M](q.componentInfoM, world.entities[entity].row, archetype)
+			if match.fetch[12] {
+				m, err = fetchComponentForQueryResult[M](q.componentInfoM, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var n N
-			if fetchN {
-				n, err = fetchComponentForQueryResult[N](q.componentInfoN, world.entities[entity].row, archetype)
+			if match.fetch[13] {
+				n, err = fetchComponentForQueryResult[N](q.componentInfoN, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var o O
-			if fetchO {
-				o, err = fetchComponentForQueryResult[O](q.componentInfoO, world.entities[entity].row, archetype)
+			if match.fetch[14] {
+				o, err = fetchComponentForQueryResult[O](q.componentInfoO, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -2390,6 +2123,9 @@ func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, Options]) Exec(wor
 			q.componentsM = append(q.componentsM, m)
 			q.componentsN = append(q.componentsN, n)
 			q.componentsO = append(q.componentsO, o)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -2401,201 +2137,146 @@ func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Options]) Exec(
 	q.ClearResults()
 
 	q.world = world
+	q.updateChangeTicks(world)
 
-	for _, archetype := range world.archetypeStorage.componentsHashToArchetype {
-		if q.options.isArchetypeFilteredOut(archetype) {
-			continue
-		}
+	if q.options.isZeroCopy {
+		// Components are read directly from the component storages during iteration.
+		return q.execZeroCopy(world)
+	}
 
-		fetchA, skip := shouldHandleQueryComponent(q.componentInfoA.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchB, skip := shouldHandleQueryComponent(q.componentInfoB.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchC, skip := shouldHandleQueryComponent(q.componentInfoC.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchD, skip := shouldHandleQueryComponent(q.componentInfoD.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchE, skip := shouldHandleQueryComponent(q.componentInfoE.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchF, skip := shouldHandleQueryComponent(q.componentInfoF.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchG, skip := shouldHandleQueryComponent(q.componentInfoG.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchH, skip := shouldHandleQueryComponent(q.componentInfoH.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchI, skip := shouldHandleQueryComponent(q.componentInfoI.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchJ, skip := shouldHandleQueryComponent(q.componentInfoJ.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchK, skip := shouldHandleQueryComponent(q.componentInfoK.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchL, skip := shouldHandleQueryComponent(q.componentInfoL.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchM, skip := shouldHandleQueryComponent(q.componentInfoM.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchN, skip := shouldHandleQueryComponent(q.componentInfoN.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchO, skip := shouldHandleQueryComponent(q.componentInfoO.id, archetype, &q.options)
-		if skip {
-			continue
-		}
-		fetchP, skip := shouldHandleQueryComponent(q.componentInfoP.id, archetype, &q.options)
-		if skip {
-			continue
-		}
+	hasChangeFilter := q.options.hasChangeFilter()
+
+	for _, match := range q.getMatchingArchetypes(world) {
+		archetype := match.archetype
+
+		for row, entity := range archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(archetype, uint(row), q.changeTicks) {
+				continue
+			}
 
-		for _, entity := range archetype.entities {
 			var a A
-			if fetchA {
-				a, err = fetchComponentForQueryResult[A](q.componentInfoA, world.entities[entity].row, archetype)
+			if match.fetch[0] {
+				a, err = fetchComponentForQueryResult[A](q.componentInfoA, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var b B
-			if fetchB {
-				b, err = fetchComponentForQueryResult[B](q.componentInfoB, world.entities[entity].row, archetype)
+			if match.fetch[1] {
+				b, err = fetchComponentForQueryResult[B](q.componentInfoB, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var c C
-			if fetchC {
-				c, err = fetchComponentForQueryResult[C](q.componentInfoC, world.entities[entity].row, archetype)
+			if match.fetch[2] {
+				c, err = fetchComponentForQueryResult[C](q.componentInfoC, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var d D
-			if fetchD {
-				d, err = fetchComponentForQueryResult[D](q.componentInfoD, world.entities[entity].row, archetype)
+			if match.fetch[3] {
+				d, err = fetchComponentForQueryResult[D](q.componentInfoD, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var e E
-			if fetchE {
-				e, err = fetchComponentForQueryResult[E](q.componentInfoE, world.entities[entity].row, archetype)
+			if match.fetch[4] {
+				e, err = fetchComponentForQueryResult[E](q.componentInfoE, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var f F
-			if fetchF {
-				f, err = fetchComponentForQueryResult[F](q.componentInfoF, world.entities[entity].row, archetype)
+			if match.fetch[5] {
+				f, err = fetchComponentForQueryResult[F](q.componentInfoF, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var g G
-			if fetchG {
-				g, err = fetchComponentForQueryResult[G](q.componentInfoG, world.entities[entity].row, archetype)
+			if match.fetch[6] {
+				g, err = fetchComponentForQueryResult[G](q.componentInfoG, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var h H
-			if fetchH {
-				h, err = fetchComponentForQueryResult[H](q.componentInfoH, world.entities[entity].row, archetype)
+			if match.fetch[7] {
+				h, err = fetchComponentForQueryResult[H](q.componentInfoH, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var i I
-			if fetchI {
-				i, err = fetchComponentForQueryResult[I](q.componentInfoI, world.entities[entity].row, archetype)
+			if match.fetch[8] {
+				i, err = fetchComponentForQueryResult[I](q.componentInfoI, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var j J
-			if fetchJ {
-				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, world.entities[entity].row, archetype)
+			if match.fetch[9] {
+				j, err = fetchComponentForQueryResult[J](q.componentInfoJ, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var k K
-			if fetchK {
-				k, err = fetchComponentForQueryResult[K](q.componentInfoK, world.entities[entity].row, archetype)
+			if match.fetch[10] {
+				k, err = fetchComponentForQueryResult[K](q.componentInfoK, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var l L
-			if fetchL {
-				l, err = fetchComponentForQueryResult[L](q.componentInfoL, world.entities[entity].row, archetype)
+			if match.fetch[11] {
+				l, err = fetchComponentForQueryResult[L](q.componentInfoL, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var m M
-			if fetchM {
-				m, err = fetchComponentForQueryResult[M](q.componentInfoM, world.entities[entity].row, archetype)
+			if match.fetch[12] {
+				m, err = fetchComponentForQueryResult[M](q.componentInfoM, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var n N
-			if fetchN {
-				n, err = fetchComponentForQueryResult[N](q.componentInfoN, world.entities[entity].row, archetype)
+			if match.fetch[13] {
+				n, err = fetchComponentForQueryResult[N](q.componentInfoN, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var o O
-			if fetchO {
-				o, err = fetchComponentForQueryResult[O](q.componentInfoO, world.entities[entity].row, archetype)
+			if match.fetch[14] {
+				o, err = fetchComponentForQueryResult[O](q.componentInfoO, uint(row), archetype)
 				if err != nil {
 					return err
 				}
 			}
 
 			var p P
-			if fetchP {
-				p, err = fetchComponentForQueryResult[P](q.componentInfoP, world.entities[entity].row, archetype)
+			if match.fetch[15] {
+				p, err = fetchComponentForQueryResult[P](q.componentInfoP, uint(row), archetype)
 				if err != nil {
 					return err
 				}
@@ -2617,6 +2298,9 @@ func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Options]) Exec(
 			q.componentsN = append(q.componentsN, n)
 			q.componentsO = append(q.componentsO, o)
 			q.componentsP = append(q.componentsP, p)
+			if q.hasPointerComponents {
+				q.markChanged(&match, uint(row))
+			}
 			q.entityIds = append(q.entityIds, entity)
 		}
 	}
@@ -2630,8 +2314,9 @@ func (q *Query0[QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*W
 		return err
 	}
 
-	q.components = []ComponentId{}
+	q.setComponents()
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query1[A, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2642,10 +2327,11 @@ func (q *Query1[A, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId
 	}
 
 	q.componentInfoA = queryComponentInfoFor[A](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query2[A, B, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2657,11 +2343,12 @@ func (q *Query2[A, B, QueryOptions]) Prepare(world *World, otherWorlds *map[Worl
 
 	q.componentInfoA = queryComponentInfoFor[A](targetWorld)
 	q.componentInfoB = queryComponentInfoFor[B](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query3[A, B, C, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2674,12 +2361,13 @@ func (q *Query3[A, B, C, QueryOptions]) Prepare(world *World, otherWorlds *map[W
 	q.componentInfoA = queryComponentInfoFor[A](targetWorld)
 	q.componentInfoB = queryComponentInfoFor[B](targetWorld)
 	q.componentInfoC = queryComponentInfoFor[C](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-		q.componentInfoC.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+		q.componentInfoC,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query4[A, B, C, D, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2693,13 +2381,14 @@ func (q *Query4[A, B, C, D, QueryOptions]) Prepare(world *World, otherWorlds *ma
 	q.componentInfoB = queryComponentInfoFor[B](targetWorld)
 	q.componentInfoC = queryComponentInfoFor[C](targetWorld)
 	q.componentInfoD = queryComponentInfoFor[D](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-		q.componentInfoC.id,
-		q.componentInfoD.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+		q.componentInfoC,
+		q.componentInfoD,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query5[A, B, C, D, E, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2714,14 +2403,15 @@ func (q *Query5[A, B, C, D, E, QueryOptions]) Prepare(world *World, otherWorlds
 	q.componentInfoC = queryComponentInfoFor[C](targetWorld)
 	q.componentInfoD = queryComponentInfoFor[D](targetWorld)
 	q.componentInfoE = queryComponentInfoFor[E](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-		q.componentInfoC.id,
-		q.componentInfoD.id,
-		q.componentInfoE.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+		q.componentInfoC,
+		q.componentInfoD,
+		q.componentInfoE,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query6[A, B, C, D, E, F, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2737,15 +2427,16 @@ func (q *Query6[A, B, C, D, E, F, QueryOptions]) Prepare(world *World, otherWorl
 	q.componentInfoD = queryComponentInfoFor[D](targetWorld)
 	q.componentInfoE = queryComponentInfoFor[E](targetWorld)
 	q.componentInfoF = queryComponentInfoFor[F](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-		q.componentInfoC.id,
-		q.componentInfoD.id,
-		q.componentInfoE.id,
-		q.componentInfoF.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+		q.componentInfoC,
+		q.componentInfoD,
+		q.componentInfoE,
+		q.componentInfoF,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query7[A, B, C, D, E, F, G, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2762,16 +2453,17 @@ func (q *Query7[A, B, C, D, E, F, G, QueryOptions]) Prepare(world *World, otherW
 	q.componentInfoE = queryComponentInfoFor[E](targetWorld)
 	q.componentInfoF = queryComponentInfoFor[F](targetWorld)
 	q.componentInfoG = queryComponentInfoFor[G](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-		q.componentInfoC.id,
-		q.componentInfoD.id,
-		q.componentInfoE.id,
-		q.componentInfoF.id,
-		q.componentInfoG.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+		q.componentInfoC,
+		q.componentInfoD,
+		q.componentInfoE,
+		q.componentInfoF,
+		q.componentInfoG,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 func (q *Query8[A, B, C, D, E, F, G, H, QueryOptions]) Prepare(world *World, otherWorlds *map[WorldId]*World) (err error) {
@@ -2789,17 +2481,18 @@ func (q *Query8[A, B, C, D, E, F, G, H, QueryOptions]) Prepare(world *World, oth
 	q.componentInfoF = queryComponentInfoFor[F](targetWorld)
 	q.componentInfoG = queryComponentInfoFor[G](targetWorld)
 	q.componentInfoH = queryComponentInfoFor[H](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id,
-		q.componentInfoB.id,
-		q.componentInfoC.id,
-		q.componentInfoD.id,
-		q.componentInfoE.id,
-		q.componentInfoF.id,
-		q.componentInfoG.id,
-		q.componentInfoH.id,
-	}
+	q.setComponents(
+		q.componentInfoA,
+		q.componentInfoB,
+		q.componentInfoC,
+		q.componentInfoD,
+		q.componentInfoE,
+		q.componentInfoF,
+		q.componentInfoG,
+		q.componentInfoH,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2819,8 +2512,9 @@ func (q *Query9[A, B, C, D, E, F, G, H, I, Options]) Prepare(world *World, other
 	q.componentInfoG = queryComponentInfoFor[G](targetWorld)
 	q.componentInfoH = queryComponentInfoFor[H](targetWorld)
 	q.componentInfoI = queryComponentInfoFor[I](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2841,8 +2535,9 @@ func (q *Query10[A, B, C, D, E, F, G, H, I, J, Options]) Prepare(world *World, o
 	q.componentInfoH = queryComponentInfoFor[H](targetWorld)
 	q.componentInfoI = queryComponentInfoFor[I](targetWorld)
 	q.componentInfoJ = queryComponentInfoFor[J](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id, q.componentInfoJ.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI, q.componentInfoJ)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2864,8 +2559,9 @@ func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, Options]) Prepare(world *World
 	q.componentInfoI = queryComponentInfoFor[I](targetWorld)
 	q.componentInfoJ = queryComponentInfoFor[J](targetWorld)
 	q.componentInfoK = queryComponentInfoFor[K](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id, q.componentInfoJ.id, q.componentInfoK.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI, q.componentInfoJ, q.componentInfoK)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2888,8 +2584,9 @@ func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, Options]) Prepare(world *Wo
 	q.componentInfoJ = queryComponentInfoFor[J](targetWorld)
 	q.componentInfoK = queryComponentInfoFor[K](targetWorld)
 	q.componentInfoL = queryComponentInfoFor[L](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id, q.componentInfoJ.id, q.componentInfoK.id, q.componentInfoL.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI, q.componentInfoJ, q.componentInfoK, q.componentInfoL)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2913,8 +2610,9 @@ func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, Options]) Prepare(world
 	q.componentInfoK = queryComponentInfoFor[K](targetWorld)
 	q.componentInfoL = queryComponentInfoFor[L](targetWorld)
 	q.componentInfoM = queryComponentInfoFor[M](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id, q.componentInfoJ.id, q.componentInfoK.id, q.componentInfoL.id, q.componentInfoM.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI, q.componentInfoJ, q.componentInfoK, q.componentInfoL, q.componentInfoM)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2939,8 +2637,9 @@ func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, Options]) Prepare(wor
 	q.componentInfoL = queryComponentInfoFor[L](targetWorld)
 	q.componentInfoM = queryComponentInfoFor[M](targetWorld)
 	q.componentInfoN = queryComponentInfoFor[N](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id, q.componentInfoJ.id, q.componentInfoK.id, q.componentInfoL.id, q.componentInfoM.id, q.componentInfoN.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI, q.componentInfoJ, q.componentInfoK, q.componentInfoL, q.componentInfoM, q.componentInfoN)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2966,8 +2665,9 @@ func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, Options]) Prepare(
 	q.componentInfoM = queryComponentInfoFor[M](targetWorld)
 	q.componentInfoN = queryComponentInfoFor[N](targetWorld)
 	q.componentInfoO = queryComponentInfoFor[O](targetWorld)
-	q.components = []ComponentId{q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id, q.componentInfoI.id, q.componentInfoJ.id, q.componentInfoK.id, q.componentInfoL.id, q.componentInfoM.id, q.componentInfoN.id, q.componentInfoO.id}
+	q.setComponents(q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH, q.componentInfoI, q.componentInfoJ, q.componentInfoK, q.componentInfoL, q.componentInfoM, q.componentInfoN, q.componentInfoO)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -2994,11 +2694,12 @@ func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Options]) Prepa
 	q.componentInfoN = queryComponentInfoFor[N](targetWorld)
 	q.componentInfoO = queryComponentInfoFor[O](targetWorld)
 	q.componentInfoP = queryComponentInfoFor[P](targetWorld)
-	q.components = []ComponentId{
-		q.componentInfoA.id, q.componentInfoB.id, q.componentInfoC.id, q.componentInfoD.id, q.componentInfoE.id, q.componentInfoF.id, q.componentInfoG.id, q.componentInfoH.id,
-		q.componentInfoI.id, q.componentInfoJ.id, q.componentInfoK.id, q.componentInfoL.id, q.componentInfoM.id, q.componentInfoN.id, q.componentInfoO.id, q.componentInfoP.id,
-	}
+	q.setComponents(
+		q.componentInfoA, q.componentInfoB, q.componentInfoC, q.componentInfoD, q.componentInfoE, q.componentInfoF, q.componentInfoG, q.componentInfoH,
+		q.componentInfoI, q.componentInfoJ, q.componentInfoK, q.componentInfoL, q.componentInfoM, q.componentInfoN, q.componentInfoO, q.componentInfoP,
+	)
 	q.options.optimize(q.components)
+	q.archetypeCache = queryArchetypeCache{}
 	return nil
 }
 
@@ -3072,272 +2773,425 @@ func fetchComponentForQueryResult[T AnyComponent](componentInfo queryComponentIn
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query0[_]) Iter(f func(entityId EntityId)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query0[_]) IterUntilErr(f func(entityId EntityId) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query1[A, _]) Iter(f func(entityId EntityId, a A)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query1[A, _]) IterUntilErr(f func(entityId EntityId, a A) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query2[A, B, _]) Iter(f func(entityId EntityId, a A, b B)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query2[A, B, _]) IterUntilErr(f func(entityId EntityId, a A, b B) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query3[A, B, C, _]) Iter(f func(entityId EntityId, a A, b B, c C)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query3[A, B, C, _]) IterUntilErr(f func(entityId EntityId, a A, b B, c C) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query4[A, B, C, D, _]) Iter(f func(entityId EntityId, a A, b B, c C, d D)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query4[A, B, C, D, _]) IterUntilErr(f func(entityId EntityId, a A, b B, c C, d D) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query5[A, B, C, D, E, _]) Iter(f func(entityId EntityId, a A, b B, c C, d D, e E)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query5[A, B, C, D, E, _]) IterUntilErr(f func(entityId EntityId, a A, b B, c C, d D, e E) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query6[A, B, C, D, E, F, _]) Iter(f func(entityId EntityId, a A, b B, c C, d D, e E, f F)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query6[A, B, C, D, E, F, _]) IterUntilErr(f func(entityId EntityId, a A, b B, c C, d D, e E, f F) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query7[A, B, C, D, E, F, G, _]) Iter(f func(entityId EntityId, a A, b B, c C, d D, e E, f F, g G)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query7[A, B, C, D, E, F, G, _]) IterUntilErr(f func(entityId EntityId, a A, b B, c C, d D, e E, f F, g G) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query8[A, B, C, D, E, F, G, H, _]) Iter(f func(entityId EntityId, a A, b B, c C, d D, e E, f F, g G, h H)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query8[A, B, C, D, E, F, G, H, _]) IterUntilErr(f func(entityId EntityId, a A, b B, c C, d D, e E, f F, g G, h H) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query9[A, B, C, D, E, F, G, H, I, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query9[A, B, C, D, E, F, G, H, I, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
 
 // Iter executes function f on each entity that the query returned.
 func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) Iter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P)) {
-	q.world.isQuerying = true
-	q.iter(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.iterZeroCopy(f)
+	} else {
+		q.iter(f)
+	}
+	q.world.stopQuerying()
 }
 
 // IterUntilErr executes function f on each entity that the query returned, until f returns an error.
 // If any of the calls to f returned an error, this function returns that error.
 func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) IterUntilErr(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P) error) error {
-	q.world.isQuerying = true
-	err := q.iterUntilErr(f)
-	q.world.isQuerying = false
+	q.world.startQuerying()
+	var err error
+	if q.options.isZeroCopy {
+		err = q.iterUntilErrZeroCopy(f)
+	} else {
+		err = q.iterUntilErr(f)
+	}
+	q.world.stopQuerying()
 	return err
 }
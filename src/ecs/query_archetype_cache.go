@@ -0,0 +1,115 @@
+package ecs
+
+import "slices"
+
+// queryArchetypeMatch is an archetype whose entities are included in the results of a query.
+type queryArchetypeMatch struct {
+	archetype *Archetype
+
+	// fetch[i] is whether the i'th queried component should be fetched from the archetype. It is false for
+	// optional components that are not present in the archetype.
+	fetch []bool
+
+	// storages[i] is the component storage of the i'th queried component, or nil if fetch[i] is false.
+	storages []*componentStorage
+}
+
+// queryArchetypeCache keeps track of the archetypes that match a query.
+//
+// Archetypes are only removed from a world in rare cases, such as when the target of a relation got despawned, so the
+// cache only has to check archetypes that got created since the last time it got updated. The cache is rebuilt when
+// any archetype got removed.
+type queryArchetypeCache struct {
+	world   *World
+	matches []queryArchetypeMatch
+
+	// The archetypes to check are taken from world.archetypeStorage.componentIdToArchetypes[candidateComponent].
+	// If hasCandidateComponent is false, the query has no required component and all archetypes are checked.
+	candidateComponent    ComponentId
+	hasCandidateComponent bool
+
+	numberOfCheckedArchetypes int
+	removals                  uint // the value of world.archetypeStorage.removals when the cache got built
+}
+
+// getMatchingArchetypes returns the archetypes of world that match the query. Only archetypes that got created
+// since the previous call are checked against the query.
+func (o *queryOptions) getMatchingArchetypes(world *World) []queryArchetypeMatch {
+	cache := &o.archetypeCache
+	if cache.world != world || cache.removals != world.archetypeStorage.removals {
+		*cache = queryArchetypeCache{world: world, removals: world.archetypeStorage.removals}
+		cache.candidateComponent, cache.hasCandidateComponent = o.getCandidateComponent(world)
+	}
+
+	candidates := world.archetypeStorage.archetypes
+	if cache.hasCandidateComponent {
+		archetypes, exists := world.archetypeStorage.componentIdToArchetypes[cache.candidateComponent]
+		if !exists {
+			return cache.matches
+		}
+		candidates = *archetypes
+	}
+
+	for _, archetype := range candidates[cache.numberOfCheckedArchetypes:] {
+		match, isMatch := o.matchArchetype(archetype)
+		if isMatch {
+			cache.matches = append(cache.matches, match)
+		}
+	}
+	cache.numberOfCheckedArchetypes = len(candidates)
+
+	return cache.matches
+}
+
+// getCandidateComponent returns the required component of the query that is present in the least number of
+// archetypes. Returns false if the query does not have any required components.
+func (o *queryOptions) getCandidateComponent(world *World) (candidate ComponentId, hasCandidate bool) {
+	numberOfArchetypes := 0
+
+	for _, componentId := range o.components {
+		if slices.Contains(o.options.OptionalComponents, componentId) {
+			continue
+		}
+
+		archetypes, exists := world.archetypeStorage.componentIdToArchetypes[componentId]
+		if !exists {
+			// no archetypes contain this component yet, so it is the best candidate
+			return componentId, true
+		}
+
+		if !hasCandidate || len(*archetypes) < numberOfArchetypes {
+			candidate = componentId
+			hasCandidate = true
+			numberOfArchetypes = len(*archetypes)
+		}
+	}
+
+	return candidate, hasCandidate
+}
+
+// matchArchetype returns whether the entities of archetype should be included in the query results.
+func (o *queryOptions) matchArchetype(archetype *Archetype) (queryArchetypeMatch, bool) {
+	if o.options.isArchetypeFilteredOut(archetype) {
+		return queryArchetypeMatch{}, false
+	}
+
+	match := queryArchetypeMatch{
+		archetype: archetype,
+		fetch:     make([]bool, len(o.components)),
+		storages:  make([]*componentStorage, len(o.components)),
+	}
+
+	for i, componentId := range o.components {
+		shouldFetch, shouldSkip := shouldHandleQueryComponent(componentId, archetype, &o.options)
+		if shouldSkip {
+			return queryArchetypeMatch{}, false
+		}
+
+		match.fetch[i] = shouldFetch
+		if shouldFetch {
+			match.storages[i] = archetype.components[componentId]
+		}
+	}
+
+	return match, true
+}
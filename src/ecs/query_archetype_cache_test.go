@@ -0,0 +1,122 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryArchetypeCache(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+	type componentC struct{ Component }
+
+	t.Run("includes archetypes that got created after the previous Exec", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		query := Query1[componentA, Default]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+		assert.Equal(uint(1), query.NumberOfResult())
+		assert.Len(query.archetypeCache.matches, 1)
+
+		_, err = Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentC{})
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+		assert.Equal(uint(2), query.NumberOfResult())
+		assert.Len(query.archetypeCache.matches, 2)
+	})
+
+	t.Run("only checks archetypes that contain a required component", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{}, &componentB{}, &componentC{})
+		assert.NoError(err)
+
+		query := Query2[componentA, componentC, Default]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		assert.Equal(uint(1), query.NumberOfResult())
+		assert.Equal(ComponentIdFor[componentC](world), query.archetypeCache.candidateComponent)
+		assert.Equal(1, query.archetypeCache.numberOfCheckedArchetypes)
+	})
+
+	t.Run("checks all archetypes if the query has no required components", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentB{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Optional1[componentA]]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		assert.Equal(uint(2), query.NumberOfResult())
+		assert.False(query.archetypeCache.hasCandidateComponent)
+		assert.Len(query.archetypeCache.matches, 2)
+	})
+
+	t.Run("does not fetch optional components that are not in the archetype", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query2[componentA, componentB, Optional1[componentB]]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		assert.Len(query.archetypeCache.matches, 1)
+		assert.Equal([]bool{true, false}, query.archetypeCache.matches[0].fetch)
+	})
+
+	t.Run("resets when executed on another world", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world1 := NewDefaultWorld()
+		_, err := Spawn(world1, &componentA{})
+		assert.NoError(err)
+		world2 := NewDefaultWorld()
+		_, err = Spawn(world2, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world2, &componentA{}, &componentB{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Default]{}
+		err = query.Prepare(world1, nil)
+		assert.NoError(err)
+
+		err = query.Exec(world1)
+		assert.NoError(err)
+		assert.Equal(uint(1), query.NumberOfResult())
+
+		err = query.Exec(world2)
+		assert.NoError(err)
+		assert.Equal(uint(2), query.NumberOfResult())
+	})
+}
@@ -0,0 +1,114 @@
+package ecs
+
+import "math"
+
+const (
+	// changeTickCheckThreshold is the number of change ticks after which the change ticks that are stored in the
+	// world get clamped, see [World.checkChangeTicks].
+	changeTickCheckThreshold uint32 = 518_400_000
+
+	// maxChangeAge is the maximum number of change ticks that a stored change tick can be older than the current
+	// change tick. Older ticks are clamped to this age, so that they never wrap around to look newer than they are.
+	maxChangeAge uint32 = math.MaxUint32 - (2*changeTickCheckThreshold - 1)
+)
+
+// queryChangeTicks are the change ticks (see [World.ChangeTick]) that a query uses for the [Added] and [Changed]
+// filters, and for marking components as changed.
+type queryChangeTicks struct {
+	// Components that got added or changed after lastRun pass the Added and Changed filters.
+	lastRun uint32
+
+	// Components that are queried as a pointer are marked as changed at thisRun.
+	thisRun uint32
+
+	// isSetBySystem is true if the query is a system param, in which case the ticks are those of the system.
+	// Otherwise the query advances the change tick of the world itself whenever it gets executed.
+	isSetBySystem bool
+}
+
+// isNewer returns whether a component that got added or changed at tick should count as added or changed.
+//
+// Structural changes that a system makes get the tick of the world at that time, which is newer than the
+// thisRun tick of all systems in the schedule. Comparing against thisRun makes sure that systems that run
+// later in the same schedule see such a change during their next run, instead of both now and during
+// their next run.
+//
+// The change tick of a world wraps around, so ticks are compared by how long ago they were relative to thisRun.
+// Ticks that are newer than thisRun are so far away when wrapped that they never count as added or changed.
+func (t queryChangeTicks) isNewer(tick uint32) bool {
+	ticksSinceLastRun := min(t.thisRun-t.lastRun, maxChangeAge)
+	ticksSinceTick := min(t.thisRun-tick, maxChangeAge)
+	return ticksSinceTick < ticksSinceLastRun
+}
+
+// clampChangeTick returns tick, or the tick that is maxChangeAge older than current if tick is older than that.
+func clampChangeTick(tick uint32, current uint32) uint32 {
+	if current-tick > maxChangeAge {
+		return current - maxChangeAge
+	}
+
+	return tick
+}
+
+// checkChangeTicks clamps the change ticks that are stored in the world to maxChangeAge, so that they keep being
+// compared correctly after the change tick of the world wrapped around. Only does so once every
+// changeTickCheckThreshold change ticks.
+func (world *World) checkChangeTicks() {
+	current := world.ChangeTick()
+	if current-world.lastChangeTickCheck < changeTickCheckThreshold {
+		return
+	}
+	world.lastChangeTickCheck = current
+
+	for _, archetype := range world.archetypeStorage.archetypes {
+		for _, storage := range archetype.components {
+			for i := range storage.ticks {
+				storage.ticks[i].added = clampChangeTick(storage.ticks[i].added, current)
+				storage.ticks[i].changed = clampChangeTick(storage.ticks[i].changed, current)
+			}
+		}
+	}
+
+	for _, log := range world.removedComponents.logs {
+		for i := range log.entries {
+			log.entries[i].changeTick = clampChangeTick(log.entries[i].changeTick, current)
+		}
+	}
+
+	for _, scheduleSystems := range world.scheduler.systems {
+		for _, system := range scheduleSystems.getSystems() {
+			system.lastRunTick = clampChangeTick(system.lastRunTick, current)
+		}
+	}
+}
+
+// setChangeTicks sets the ticks of the system that the query is a param of.
+func (o *queryOptions) setChangeTicks(lastRun uint32, thisRun uint32) {
+	o.changeTicks = queryChangeTicks{
+		lastRun:       lastRun,
+		thisRun:       thisRun,
+		isSetBySystem: true,
+	}
+}
+
+// updateChangeTicks advances the ticks of the query if they are not set by a system.
+func (o *queryOptions) updateChangeTicks(world *World) {
+	if o.changeTicks.isSetBySystem {
+		return
+	}
+
+	o.changeTicks.lastRun = o.changeTicks.thisRun
+	o.changeTicks.thisRun = world.advanceChangeTick()
+
+	// Advance once more so that changes that are made after this Exec are newer than thisRun.
+	world.advanceChangeTick()
+}
+
+// markChanged marks the components at row of match that are queried as a pointer as changed.
+func (o *queryOptions) markChanged(match *queryArchetypeMatch, row uint) {
+	for i := range o.componentInfos {
+		if o.componentInfos[i].isPointer && match.storages[i] != nil {
+			match.storages[i].markChanged(row, o.changeTicks.thisRun)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package ecs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeDetection(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct{ Component }
+
+	t.Run("Added includes components that got added since the previous Exec", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query0[Added[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(0), query.NumberOfResult())
+
+		entity, err := Spawn(world, &componentB{})
+		assert.NoError(err)
+		assert.NoError(Insert(world, entity, &componentA{}))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+	})
+
+	t.Run("Added does not include components that moved to another archetype", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Added[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+
+		assert.NoError(Insert(world, entity, &componentB{}))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(0), query.NumberOfResult())
+
+		assert.NoError(Remove1[componentB](world, entity))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(0), query.NumberOfResult())
+	})
+
+	t.Run("Changed includes components that got overwritten", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Changed[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(2), query.NumberOfResult())
+
+		assert.NoError(InsertOrOverwrite(world, entity, &componentA{value: 1}))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+		assert.Equal(entity, query.entityIds[0])
+	})
+
+	t.Run("Changed includes components that got queried as a pointer", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+
+		changedQuery := Query1[componentA, Changed[componentA]]{}
+		assert.NoError(changedQuery.Prepare(world, nil))
+		assert.NoError(changedQuery.Exec(world))
+
+		pointerQuery := Query1[*componentA, Without[componentB]]{}
+		assert.NoError(pointerQuery.Prepare(world, nil))
+		assert.NoError(pointerQuery.Exec(world))
+
+		valueQuery := Query1[componentA, Default]{}
+		assert.NoError(valueQuery.Prepare(world, nil))
+		assert.NoError(valueQuery.Exec(world))
+
+		assert.NoError(changedQuery.Exec(world))
+		assert.Equal(uint(1), changedQuery.NumberOfResult())
+		assert.Equal(entity, changedQuery.entityIds[0])
+	})
+
+	t.Run("Changed includes components that got modified through Mut", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Changed[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+
+		mut, err := GetMut[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(0, mut.Get().value)
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(0), query.NumberOfResult())
+
+		mut, err = GetMut[componentA](world, entity)
+		assert.NoError(err)
+		mut.Set(componentA{value: 5})
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+		assert.Equal(5, query.componentsA[0].value)
+
+		mut, err = GetMut[componentA](world, entity)
+		assert.NoError(err)
+		mut.Ptr().value = 6
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+		assert.Equal(6, query.componentsA[0].value)
+	})
+
+	t.Run("GetMut returns an error for pointer components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		_, err = GetMut[*componentA](world, entity)
+		assert.ErrorIs(err, ErrMutPointerComponent)
+		_, err = GetMut[componentB](world, entity)
+		assert.ErrorIs(err, ErrComponentNotFound)
+	})
+
+	t.Run("change filters can be combined with Or", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+
+		query := Query0[Or[Added[componentA], With[componentB]]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(2), query.NumberOfResult())
+
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+	})
+
+	t.Run("ticks stay with their component when other entities get removed", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entityA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query0[Added[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+
+		entityB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.NoError(Despawn(world, entityA))
+
+		assert.NoError(query.Exec(world))
+		assert.Equal([]EntityId{entityB}, query.entityIds)
+	})
+
+	t.Run("change filters are not supported by zero-copy queries and chunks", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		zeroCopyQuery := Query1[componentA, QueryOptions2[Changed[componentA], ZeroCopy]]{}
+		assert.NoError(zeroCopyQuery.Prepare(world, nil))
+		assert.ErrorIs(zeroCopyQuery.Exec(world), ErrQueryChangeFilterNotSupported)
+
+		query := Query1[componentA, Changed[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		err = query.IterChunks(func(entityIds []EntityId, a []componentA) {})
+		assert.ErrorIs(err, ErrQueryChangeFilterNotSupported)
+	})
+
+	t.Run("systems compare against their own last run", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		numberOfChanged := []uint{}
+		shouldWrite := true
+		err = scheduleSystems.add(Systems(
+			func(query *Query1[componentA, Changed[componentA]]) {
+				numberOfChanged = append(numberOfChanged, query.NumberOfResult())
+			},
+			func(query *Query1[*componentA, Lazy]) error {
+				if !shouldWrite {
+					return nil
+				}
+				return query.Exec(world)
+			},
+		), "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		// first run: the component got added before the system ran for the first time
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		// second run: the second system changed the component after the first system ran
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 1))
+		shouldWrite = false
+		// third run: the second system changed the component after the previous run of the first system
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 2))
+		// fourth run: nothing changed
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 3))
+
+		assert.Equal([]uint{1, 1, 1, 0}, numberOfChanged)
+	})
+
+	t.Run("systems see components that an earlier system added exactly once", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+		entity, err := Spawn(world, &componentB{})
+		assert.NoError(err)
+
+		numberOfAdded := []uint{}
+		err = scheduleSystems.add(Systems(
+			func() error {
+				if len(numberOfAdded) == 0 {
+					return Insert(world, entity, &componentA{})
+				}
+				return nil
+			},
+			func(query *Query0[Added[componentA]]) {
+				numberOfAdded = append(numberOfAdded, query.NumberOfResult())
+			},
+		), "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 1))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 2))
+
+		assert.Equal([]uint{0, 1, 0}, numberOfAdded)
+	})
+
+	t.Run("change ticks are compared correctly when the change tick wraps around", func(t *testing.T) {
+		assert := assert.New(t)
+
+		changeTicks := queryChangeTicks{lastRun: math.MaxUint32 - 1, thisRun: 2}
+		assert.True(changeTicks.isNewer(math.MaxUint32))
+		assert.True(changeTicks.isNewer(0))
+		assert.True(changeTicks.isNewer(2))
+		assert.False(changeTicks.isNewer(math.MaxUint32 - 1))
+		assert.False(changeTicks.isNewer(math.MaxUint32 - 10))
+		assert.False(changeTicks.isNewer(3))
+
+		world := NewDefaultWorld()
+		world.changeTickAdvances.Store(math.MaxUint32 - 5)
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Changed[componentA]]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+
+		for range 5 {
+			assert.NoError(query.Exec(world))
+			assert.Equal(uint(0), query.NumberOfResult())
+		}
+
+		assert.NoError(InsertOrOverwrite(world, entity, &componentA{value: 1}))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(1), query.NumberOfResult())
+		assert.Less(world.ChangeTick(), uint32(10))
+	})
+
+	t.Run("checkChangeTicks clamps change ticks that are too old", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		assert.NoError(world.AddSchedule("Update", nil, false))
+		assert.NoError(world.AddSystem("Update", func() {}))
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		world.checkChangeTicks()
+		assert.Equal(componentTicks{added: 1, changed: 1}, world.archetypeStorage.archetypes[0].components[ComponentIdFor[componentA](world)].ticks[0])
+
+		world.changeTickAdvances.Store(maxChangeAge + 10)
+		current := world.ChangeTick()
+		world.checkChangeTicks()
+
+		ticks := world.archetypeStorage.archetypes[0].components[ComponentIdFor[componentA](world)].ticks[0]
+		assert.Equal(componentTicks{added: current - maxChangeAge, changed: current - maxChangeAge}, ticks)
+		scheduleSystems, err := world.GetScheduleSystems()
+		assert.NoError(err)
+		assert.Equal(current-maxChangeAge, scheduleSystems[0].getSystems()[0].lastRunTick)
+	})
+}
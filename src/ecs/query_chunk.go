@@ -0,0 +1,676 @@
+package ecs
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// chunkComponents returns the first length components of storage as a slice that shares its memory with
+// storage. Returns nil if storage is nil, which is the case for optional components that are not present
+// in the archetype.
+func chunkComponents[T AnyComponent](storage *componentStorage, length int) []T {
+	if storage == nil {
+		return nil
+	}
+
+	return unsafe.Slice((*T)(storage.pointerToStart), length)
+}
+
+// chunkEntities returns the entities of archetype. The capacity of the slice is limited so that appending to
+// it does not affect the archetype.
+func chunkEntities(archetype *Archetype) []EntityId {
+	return archetype.entities[:len(archetype.entities):len(archetype.entities)]
+}
+
+// validateChunks returns an [ErrQueryChunkPointerComponent] error if any of the queried components is a pointer,
+// because the component storages store values. Returns an [ErrQueryChangeFilterNotSupported] error if the query
+// has an [Added] or [Changed] filter, because chunks can not skip entities. Returns an [ErrQueryChunkNotZeroCopy]
+// error if the query does not have the [ZeroCopy] option, because only the components of zero-copy queries are
+// written to according to the access of systems, see [systemAccess.addQuery].
+func (o *queryOptions) validateChunks() error {
+	if o.options.hasChangeFilter() {
+		return fmt.Errorf("%w: chunks", ErrQueryChangeFilterNotSupported)
+	}
+
+	if !o.options.isZeroCopy {
+		return ErrQueryChunkNotZeroCopy
+	}
+
+	for _, componentInfo := range o.componentInfos {
+		if componentInfo.isPointer {
+			return fmt.Errorf("%w: %s", ErrQueryChunkPointerComponent, componentInfo.id.DebugString())
+		}
+	}
+
+	return nil
+}
+
+// markChunkChanged marks all components of match as changed, because they can be changed through the chunk.
+func (o *queryOptions) markChunkChanged(match *queryArchetypeMatch) {
+	for _, storage := range match.storages {
+		if storage == nil {
+			continue
+		}
+
+		for row := range match.archetype.entities {
+			storage.markChanged(uint(row), o.changeTicks.thisRun)
+		}
+	}
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, and
+// an [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query0[_]) IterChunks(f func(entityIds []EntityId)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		f(chunkEntities(match.archetype))
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query1[A, _]) IterChunks(f func(entityIds []EntityId, a []A)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(entityIds, chunkComponents[A](match.storages[0], len(entityIds)))
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query2[A, B, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query3[A, B, C, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B, c []C)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query4[A, B, C, D, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B, c []C, d []D)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query5[A, B, C, D, E, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B, c []C, d []D, e []E)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query6[A, B, C, D, E, F, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B, c []C, d []D, e []E, f []F)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query7[A, B, C, D, E, F, G, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B, c []C, d []D, e []E, f []F, g []G)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query8[A, B, C, D, E, F, G, H, _]) IterChunks(f func(entityIds []EntityId, a []A, b []B, c []C, d []D, e []E, f []F, g []G, h []H)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query9[A, B, C, D, E, F, G, H, I, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J, []K)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+			chunkComponents[K](match.storages[10], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J, []K, []L)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+			chunkComponents[K](match.storages[10], len(entityIds)),
+			chunkComponents[L](match.storages[11], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J, []K, []L, []M)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+			chunkComponents[K](match.storages[10], len(entityIds)),
+			chunkComponents[L](match.storages[11], len(entityIds)),
+			chunkComponents[M](match.storages[12], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J, []K, []L, []M, []N)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+			chunkComponents[K](match.storages[10], len(entityIds)),
+			chunkComponents[L](match.storages[11], len(entityIds)),
+			chunkComponents[M](match.storages[12], len(entityIds)),
+			chunkComponents[N](match.storages[13], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J, []K, []L, []M, []N, []O)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+			chunkComponents[K](match.storages[10], len(entityIds)),
+			chunkComponents[L](match.storages[11], len(entityIds)),
+			chunkComponents[M](match.storages[12], len(entityIds)),
+			chunkComponents[N](match.storages[13], len(entityIds)),
+			chunkComponents[O](match.storages[14], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
+
+// IterChunks executes function f once for each archetype that matched the query, with the entities of that
+// archetype and their components. Must be called after Exec. The query must have the [ZeroCopy] option.
+//
+// The component slices share their memory with the component storages, so changes made to them are applied
+// directly to the components. Because of that, all components of the chunks are marked as changed, just like
+// components that are queried as a pointer. Optional components that are not present in the archetype are nil slices.
+//
+// Returns an [ErrQueryChunkNotZeroCopy] error, without calling f, if the query does not have the ZeroCopy option, an
+// [ErrQueryChunkPointerComponent] error if any queried component is a pointer, and an
+// [ErrQueryChangeFilterNotSupported] error if the query has an [Added] or [Changed] filter.
+func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) IterChunks(f func([]EntityId, []A, []B, []C, []D, []E, []F, []G, []H, []I, []J, []K, []L, []M, []N, []O, []P)) error {
+	err := q.validateChunks()
+	if err != nil {
+		return err
+	}
+
+	q.world.startQuerying()
+	for _, match := range q.archetypeCache.matches {
+		entityIds := chunkEntities(match.archetype)
+		q.markChunkChanged(&match)
+		f(
+			entityIds,
+			chunkComponents[A](match.storages[0], len(entityIds)),
+			chunkComponents[B](match.storages[1], len(entityIds)),
+			chunkComponents[C](match.storages[2], len(entityIds)),
+			chunkComponents[D](match.storages[3], len(entityIds)),
+			chunkComponents[E](match.storages[4], len(entityIds)),
+			chunkComponents[F](match.storages[5], len(entityIds)),
+			chunkComponents[G](match.storages[6], len(entityIds)),
+			chunkComponents[H](match.storages[7], len(entityIds)),
+			chunkComponents[I](match.storages[8], len(entityIds)),
+			chunkComponents[J](match.storages[9], len(entityIds)),
+			chunkComponents[K](match.storages[10], len(entityIds)),
+			chunkComponents[L](match.storages[11], len(entityIds)),
+			chunkComponents[M](match.storages[12], len(entityIds)),
+			chunkComponents[N](match.storages[13], len(entityIds)),
+			chunkComponents[O](match.storages[14], len(entityIds)),
+			chunkComponents[P](match.storages[15], len(entityIds)),
+		)
+	}
+	q.world.stopQuerying()
+
+	return nil
+}
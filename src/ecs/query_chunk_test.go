@@ -0,0 +1,204 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryIterChunks(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct {
+		Component
+		value int
+	}
+	type componentC struct{ Component }
+
+	t.Run("yields one chunk per matching archetype", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity1, err := Spawn(world, &componentA{value: 1}, &componentB{value: 10})
+		assert.NoError(err)
+		entity2, err := Spawn(world, &componentA{value: 2}, &componentB{value: 20})
+		assert.NoError(err)
+		entity3, err := Spawn(world, &componentA{value: 3}, &componentB{value: 30}, &componentC{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{value: 4})
+		assert.NoError(err)
+
+		query := Query2[componentA, componentB, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		numberOfChunks := 0
+		results := map[EntityId]int{}
+		err = query.IterChunks(func(entityIds []EntityId, a []componentA, b []componentB) {
+			assert.True(world.isQuerying())
+			assert.Len(a, len(entityIds))
+			assert.Len(b, len(entityIds))
+
+			numberOfChunks++
+			for i := range entityIds {
+				results[entityIds[i]] = a[i].value + b[i].value
+			}
+		})
+		assert.NoError(err)
+		assert.False(world.isQuerying())
+
+		assert.Equal(2, numberOfChunks)
+		assert.Equal(map[EntityId]int{entity1: 11, entity2: 22, entity3: 33}, results)
+	})
+
+	t.Run("writes to the chunk update the components", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{value: 1})
+		assert.NoError(err)
+
+		query := Query1[componentA, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		err = query.IterChunks(func(entityIds []EntityId, a []componentA) {
+			for i := range a {
+				a[i].value = 100
+			}
+		})
+		assert.NoError(err)
+
+		a, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(100, a.value)
+	})
+
+	t.Run("components of the chunks are marked as changed", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{value: 1})
+		assert.NoError(err)
+
+		changed := Query1[componentA, Changed[componentA]]{}
+		err = changed.Prepare(world, nil)
+		assert.NoError(err)
+		err = changed.Exec(world)
+		assert.NoError(err)
+		err = changed.Exec(world)
+		assert.NoError(err)
+		assert.Equal(uint(0), changed.NumberOfResult())
+
+		query := Query1[componentA, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+		err = query.IterChunks(func(entityIds []EntityId, a []componentA) {
+			for i := range a {
+				a[i].value = 100
+			}
+		})
+		assert.NoError(err)
+
+		err = changed.Exec(world)
+		assert.NoError(err)
+		assert.Equal(uint(1), changed.NumberOfResult())
+	})
+
+	t.Run("optional components that are not present are nil", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query2[componentA, componentB, QueryOptions2[Optional1[componentB], ZeroCopy]]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		err = query.IterChunks(func(entityIds []EntityId, a []componentA, b []componentB) {
+			assert.Len(a, 1)
+			assert.Nil(b)
+		})
+		assert.NoError(err)
+	})
+
+	t.Run("returns an error if the query is not zero-copy", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, Default]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		isCalled := false
+		err = query.IterChunks(func(entityIds []EntityId, a []componentA) {
+			isCalled = true
+		})
+		assert.ErrorIs(err, ErrQueryChunkNotZeroCopy)
+		assert.False(isCalled)
+	})
+
+	t.Run("returns an error for pointer components", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[*componentA, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		isCalled := false
+		err = query.IterChunks(func(entityIds []EntityId, a []*componentA) {
+			isCalled = true
+		})
+		assert.ErrorIs(err, ErrQueryChunkPointerComponent)
+		assert.False(isCalled)
+	})
+
+	t.Run("appending to the entities does not affect the archetype", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		for range 3 {
+			_, err := Spawn(world, &componentA{})
+			assert.NoError(err)
+		}
+
+		query := Query0[ZeroCopy]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		archetype := query.archetypeCache.matches[0].archetype
+		assert.Greater(cap(archetype.entities), len(archetype.entities))
+
+		marker := EntityId{index: 999}
+		err = query.IterChunks(func(entityIds []EntityId) {
+			_ = append(entityIds, marker)
+		})
+		assert.NoError(err)
+
+		assert.NotEqual(marker, archetype.entities[:len(archetype.entities)+1][len(archetype.entities)])
+	})
+}
@@ -0,0 +1,241 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"unsafe"
+
+	"github.com/lucdrenth/murphecs/src/utils"
+)
+
+// DynamicQuery queries components of which the ids are only known at runtime, such as in a world inspector or a
+// scripting bridge. It uses the same archetype matching as the typed queries ([Query1] and so on), but its components,
+// optional components and filters are given as data instead of as type parameters:
+//
+//	query, err := ecs.NewDynamicQuery(world, []ecs.ComponentId{positionId, velocityId}, ecs.DynamicQueryOptions{
+//		Filters:  []ecs.QueryFilter{ecs.DynamicWithout(hiddenId)},
+//		Optional: []ecs.ComponentId{velocityId},
+//	})
+//
+// Exec must be called before iterating the results. The components are read from the component storages during
+// iteration, so the results are only valid until the world gets changed, such as by spawning an entity.
+type DynamicQuery struct {
+	world *World
+
+	queryOptions
+	results []dynamicQueryResult
+}
+
+// DynamicQueryOptions are the options of a [DynamicQuery].
+type DynamicQueryOptions struct {
+	// Filters filters out entities, see [DynamicWith], [DynamicWithout], [DynamicAnd], [DynamicOr], [DynamicAdded] and
+	// [DynamicChanged]. Multiple filters are combined with an AND operator.
+	Filters []QueryFilter
+
+	// Optional are the components that entities do not have to have in order to be included in the results. They must
+	// be components of the query.
+	Optional []ComponentId
+
+	// Mutable are the components that can be changed through [DynamicQueryRow.Pointer] and
+	// [DynamicQueryRow.Value]. They are marked as changed for each result, just like components that are queried as a
+	// pointer in typed queries. They must be components of the query.
+	Mutable []ComponentId
+}
+
+type dynamicQueryResult struct {
+	entity EntityId
+	match  int // index in to queryOptions.archetypeCache.matches
+	row    uint
+}
+
+// NewDynamicQuery creates a query for components of world. The results of the query contain the components in the
+// same order as given.
+//
+// Can return the following errors:
+//   - Returns an ErrQueryInvalidComponentId error if any of the components is not a valid component id.
+//   - Returns an ErrComponentDuplicate error if a component is given multiple times.
+//   - Returns an ErrQueryComponentNotInQuery error if an optional or mutable component is not a component of the query.
+func NewDynamicQuery(world *World, components []ComponentId, options DynamicQueryOptions) (*DynamicQuery, error) {
+	for _, component := range components {
+		if component.componentType == nil {
+			return nil, ErrQueryInvalidComponentId
+		}
+	}
+
+	if duplicate, _, _ := utils.GetFirstDuplicate(components); duplicate != nil {
+		return nil, fmt.Errorf("%w: %s", ErrComponentDuplicate, duplicate.DebugString())
+	}
+
+	for _, component := range slices.Concat(options.Optional, options.Mutable) {
+		if !slices.Contains(components, component) {
+			return nil, fmt.Errorf("%w: %s", ErrQueryComponentNotInQuery, component.DebugString())
+		}
+	}
+
+	componentInfos := make([]queryComponentInfo, len(components))
+	for i, component := range components {
+		componentInfos[i] = queryComponentInfo{
+			id:        component,
+			isPointer: slices.Contains(options.Mutable, component),
+		}
+	}
+
+	query := &DynamicQuery{world: world}
+	query.options.Filters = slices.DeleteFunc(slices.Clone(options.Filters), func(filter QueryFilter) bool {
+		return filter == nil
+	})
+	query.options.OptionalComponents = slices.Clone(options.Optional)
+	query.setComponents(componentInfos...)
+
+	return query, nil
+}
+
+// Exec fills the results of the query with the entities of the world of the query that match it.
+func (q *DynamicQuery) Exec() error {
+	q.results = q.results[:0]
+	q.updateChangeTicks(q.world)
+
+	hasChangeFilter := q.options.hasChangeFilter()
+	matches := q.getMatchingArchetypes(q.world)
+
+	for i := range matches {
+		for row, entity := range matches[i].archetype.entities {
+			if hasChangeFilter && !q.options.rowMeetsCriteria(matches[i].archetype, uint(row), q.changeTicks) {
+				continue
+			}
+
+			if q.hasPointerComponents {
+				q.markChanged(&matches[i], uint(row))
+			}
+			q.results = append(q.results, dynamicQueryResult{entity: entity, match: i, row: uint(row)})
+		}
+	}
+
+	return nil
+}
+
+// Components returns the components of the query, in the order in which they were given to [NewDynamicQuery].
+func (q *DynamicQuery) Components() []ComponentId {
+	return slices.Clone(q.components)
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *DynamicQuery) NumberOfResult() uint {
+	return uint(len(q.results))
+}
+
+// ClearResults clears the query results that got filled when last running Exec.
+func (q *DynamicQuery) ClearResults() {
+	q.results = q.results[:0]
+}
+
+// Iter executes function f on each entity that the query returned.
+func (q *DynamicQuery) Iter(f func(entityId EntityId, row DynamicQueryRow)) {
+	q.world.startQuerying()
+	defer q.world.stopQuerying()
+
+	for _, result := range q.results {
+		f(result.entity, q.row(result))
+	}
+}
+
+// IterUntilErr executes function f on each entity that the query returned, until f returns an error.
+// If any of the calls to f returned an error, this function returns that error.
+func (q *DynamicQuery) IterUntilErr(f func(entityId EntityId, row DynamicQueryRow) error) error {
+	q.world.startQuerying()
+	defer q.world.stopQuerying()
+
+	for _, result := range q.results {
+		if err := f(result.entity, q.row(result)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *DynamicQuery) row(result dynamicQueryResult) DynamicQueryRow {
+	match := &q.archetypeCache.matches[result.match]
+	return DynamicQueryRow{
+		storages:       match.storages,
+		componentInfos: q.componentInfos,
+		row:            result.row,
+	}
+}
+
+// DynamicQueryRow gives access to the components of a single result of a [DynamicQuery]. Component i is the i'th
+// component that was given to [NewDynamicQuery].
+type DynamicQueryRow struct {
+	storages       []*componentStorage
+	componentInfos []queryComponentInfo
+	row            uint
+}
+
+// Has returns whether the entity has component i. This is false for optional components that the entity does not have.
+func (row DynamicQueryRow) Has(i int) bool {
+	return row.storages[i] != nil
+}
+
+// Pointer returns a pointer to component i in its component storage, or nil if the entity does not have it. The
+// pointer is only valid during iteration. Changes through the pointer are only seen by the [Changed] filter if the
+// component is mutable, see [DynamicQueryOptions].
+func (row DynamicQueryRow) Pointer(i int) unsafe.Pointer {
+	storage := row.storages[i]
+	if storage == nil {
+		return nil
+	}
+
+	return unsafe.Add(storage.pointerToStart, uintptr(row.row)*storage.componentSize)
+}
+
+// Value returns component i, or an invalid [reflect.Value] if the entity does not have it. The value of a mutable
+// component (see [DynamicQueryOptions]) is the component in its component storage and can be set during iteration.
+// The value of other components is a copy.
+func (row DynamicQueryRow) Value(i int) reflect.Value {
+	pointer := row.Pointer(i)
+	if pointer == nil {
+		return reflect.Value{}
+	}
+
+	value := reflect.NewAt(row.componentInfos[i].id.componentType, pointer).Elem()
+	if row.componentInfos[i].isPointer {
+		return value
+	}
+
+	result := reflect.New(value.Type()).Elem()
+	result.Set(value)
+	return result
+}
+
+// DynamicWith returns a filter that only includes entities that have all of components, just like [With].
+func DynamicWith(components ...ComponentId) QueryFilter {
+	return &queryFilterWith{c: slices.Clone(components)}
+}
+
+// DynamicWithout returns a filter that only includes entities that have none of components, just like [Without].
+func DynamicWithout(components ...ComponentId) QueryFilter {
+	return &queryFilterWithout{c: slices.Clone(components)}
+}
+
+// DynamicAnd returns a filter that only includes entities that pass both a and b, just like [And].
+func DynamicAnd(a, b QueryFilter) QueryFilter {
+	return &queryFilterAnd{a: a, b: b}
+}
+
+// DynamicOr returns a filter that only includes entities that pass a or b, just like [Or].
+func DynamicOr(a, b QueryFilter) QueryFilter {
+	return &queryFilterOr{a: a, b: b}
+}
+
+// DynamicAdded returns a filter that only includes entities of which component got added since the query last ran,
+// just like [Added].
+func DynamicAdded(component ComponentId) QueryFilter {
+	return &queryFilterAdded{c: component}
+}
+
+// DynamicChanged returns a filter that only includes entities of which component got added or changed since the
+// query last ran, just like [Changed].
+func DynamicChanged(component ComponentId) QueryFilter {
+	return &queryFilterChanged{c: component}
+}
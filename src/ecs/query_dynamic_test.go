@@ -0,0 +1,242 @@
+package ecs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicQuery(t *testing.T) {
+	type componentA struct {
+		Component
+		Value int
+	}
+	type componentB struct {
+		Component
+		Value string
+	}
+	type componentC struct{ Component }
+
+	t.Run("returns the entities that have all components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+		idB := ComponentIdFor[componentB](world)
+
+		expected, err := Spawn(world, &componentA{Value: 1}, &componentB{Value: "b"})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{Value: 2})
+		assert.NoError(err)
+
+		query, err := NewDynamicQuery(world, []ComponentId{idB, idA}, DynamicQueryOptions{})
+		assert.NoError(err)
+		assert.Equal([]ComponentId{idB, idA}, query.Components())
+		assert.NoError(query.Exec())
+		assert.Equal(uint(1), query.NumberOfResult())
+
+		query.Iter(func(entityId EntityId, row DynamicQueryRow) {
+			assert.Equal(expected, entityId)
+			assert.True(row.Has(0))
+			assert.Equal("b", (*componentB)(row.Pointer(0)).Value)
+			assert.Equal(componentA{Value: 1}, row.Value(1).Interface())
+		})
+
+		query.ClearResults()
+		assert.Equal(uint(0), query.NumberOfResult())
+	})
+
+	t.Run("optional components do not have to be present", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+		idB := ComponentIdFor[componentB](world)
+
+		withB, err := Spawn(world, &componentA{}, &componentB{Value: "b"})
+		assert.NoError(err)
+		withoutB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query, err := NewDynamicQuery(world, []ComponentId{idA, idB}, DynamicQueryOptions{Optional: []ComponentId{idB}})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+		assert.Equal(uint(2), query.NumberOfResult())
+
+		query.Iter(func(entityId EntityId, row DynamicQueryRow) {
+			switch entityId {
+			case withB:
+				assert.True(row.Has(1))
+				assert.Equal("b", row.Value(1).FieldByName("Value").String())
+			case withoutB:
+				assert.False(row.Has(1))
+				assert.Nil(row.Pointer(1))
+				assert.False(row.Value(1).IsValid())
+			default:
+				assert.FailNow("returned unexpected entity", entityId)
+			}
+		})
+	})
+
+	t.Run("filters out entities", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+		idB := ComponentIdFor[componentB](world)
+		idC := ComponentIdFor[componentC](world)
+
+		onlyA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		withB, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{}, &componentC{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentB{})
+		assert.NoError(err)
+
+		query, err := NewDynamicQuery(world, []ComponentId{idA}, DynamicQueryOptions{
+			Filters: []QueryFilter{DynamicOr(DynamicWith(idB), DynamicWithout(idB, idC))},
+		})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+
+		entities := []EntityId{}
+		query.Iter(func(entityId EntityId, row DynamicQueryRow) {
+			entities = append(entities, entityId)
+		})
+		assert.ElementsMatch([]EntityId{onlyA, withB}, entities)
+
+		query, err = NewDynamicQuery(world, nil, DynamicQueryOptions{
+			Filters: []QueryFilter{DynamicAnd(DynamicWith(idA), DynamicWith(idC))},
+		})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+		assert.Equal(uint(1), query.NumberOfResult())
+	})
+
+	t.Run("mutable components can be changed and are marked as changed", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+		idB := ComponentIdFor[componentB](world)
+
+		entity, err := Spawn(world, &componentA{Value: 1}, &componentB{Value: "b"})
+		assert.NoError(err)
+
+		changed, err := NewDynamicQuery(world, []ComponentId{idA}, DynamicQueryOptions{
+			Filters: []QueryFilter{DynamicChanged(idA)},
+		})
+		assert.NoError(err)
+		assert.NoError(changed.Exec())
+		assert.Equal(uint(1), changed.NumberOfResult())
+		assert.NoError(changed.Exec())
+		assert.Equal(uint(0), changed.NumberOfResult())
+
+		query, err := NewDynamicQuery(world, []ComponentId{idA, idB}, DynamicQueryOptions{Mutable: []ComponentId{idA}})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+		query.Iter(func(entityId EntityId, row DynamicQueryRow) {
+			row.Value(0).FieldByName("Value").SetInt(5)
+			row.Value(1).FieldByName("Value").SetString("changed copy")
+		})
+
+		a, err := Get1[componentA](world, entity)
+		assert.NoError(err)
+		assert.Equal(5, a.Value)
+		b, err := Get1[componentB](world, entity)
+		assert.NoError(err)
+		assert.Equal("b", b.Value)
+
+		assert.NoError(changed.Exec())
+		assert.Equal(uint(1), changed.NumberOfResult())
+	})
+
+	t.Run("returns only entities of which the component got added", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query, err := NewDynamicQuery(world, nil, DynamicQueryOptions{Filters: []QueryFilter{DynamicAdded(idA)}})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+		assert.Equal(uint(1), query.NumberOfResult())
+
+		added, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+		assert.Equal(uint(1), query.NumberOfResult())
+		query.Iter(func(entityId EntityId, row DynamicQueryRow) {
+			assert.Equal(added, entityId)
+		})
+	})
+
+	t.Run("IterUntilErr stops at the first error", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+
+		for range 3 {
+			_, err := Spawn(world, &componentA{})
+			assert.NoError(err)
+		}
+
+		query, err := NewDynamicQuery(world, []ComponentId{idA}, DynamicQueryOptions{})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+
+		expectedErr := errors.New("stop")
+		calls := 0
+		err = query.IterUntilErr(func(entityId EntityId, row DynamicQueryRow) error {
+			calls++
+			return expectedErr
+		})
+		assert.ErrorIs(err, expectedErr)
+		assert.Equal(1, calls)
+	})
+
+	t.Run("returns an error for invalid components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		idA := ComponentIdFor[componentA](world)
+		idB := ComponentIdFor[componentB](world)
+
+		_, err := NewDynamicQuery(world, []ComponentId{{}}, DynamicQueryOptions{})
+		assert.ErrorIs(err, ErrQueryInvalidComponentId)
+
+		_, err = NewDynamicQuery(world, []ComponentId{idA, idA}, DynamicQueryOptions{})
+		assert.ErrorIs(err, ErrComponentDuplicate)
+
+		_, err = NewDynamicQuery(world, []ComponentId{idA}, DynamicQueryOptions{Optional: []ComponentId{idB}})
+		assert.ErrorIs(err, ErrQueryComponentNotInQuery)
+
+		_, err = NewDynamicQuery(world, []ComponentId{idA}, DynamicQueryOptions{Mutable: []ComponentId{idB}})
+		assert.ErrorIs(err, ErrQueryComponentNotInQuery)
+	})
+
+	t.Run("queries relation pairs", func(t *testing.T) {
+		type likes struct{ Relation }
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		target, err := Spawn(world)
+		assert.NoError(err)
+		entity, err := Spawn(world)
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, entity, target, &likes{}))
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		pair := RelationIdFor[*likes](world, target)
+		query, err := NewDynamicQuery(world, []ComponentId{pair}, DynamicQueryOptions{})
+		assert.NoError(err)
+		assert.NoError(query.Exec())
+		assert.Equal(uint(1), query.NumberOfResult())
+		query.Iter(func(entityId EntityId, row DynamicQueryRow) {
+			assert.Equal(entity, entityId)
+			assert.Equal(reflect.TypeFor[likes](), row.Value(0).Type())
+		})
+	})
+}
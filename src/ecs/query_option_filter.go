@@ -15,6 +15,8 @@ const (
 	filterTypeAnd
 	filterTypeOr
 	filterTypeNone
+	filterTypeAdded
+	filterTypeChanged
 )
 
 type QueryParamFilter interface {
@@ -28,6 +30,15 @@ type Without[A AnyComponent] struct{}
 type And[A, B QueryParamFilter] struct{}
 type Or[A, B QueryParamFilter] struct{}
 
+// Added filters out entities whose component A did not get added since the system, or the query if it is
+// not a system param, last ran. Added can not be used together with [ZeroCopy] or [Query1.IterChunks].
+type Added[A AnyComponent] struct{}
+
+// Changed filters out entities whose component A did not get added or changed since the system, or the query
+// if it is not a system param, last ran. Components are changed by [InsertOrOverwrite], by being queried as
+// a pointer and by [Mut.Set]. Changed can not be used together with [ZeroCopy] or [Query1.IterChunks].
+type Changed[A AnyComponent] struct{}
+
 func (filter NoFilter) getComponents(world *World) []ComponentId {
 	return []ComponentId{}
 }
@@ -48,6 +59,14 @@ func (filter Without[A]) getComponents(world *World) []ComponentId {
 	return []ComponentId{ComponentIdFor[A](world)}
 }
 
+func (filter Added[A]) getComponents(world *World) []ComponentId {
+	return []ComponentId{ComponentIdFor[A](world)}
+}
+
+func (filter Changed[A]) getComponents(world *World) []ComponentId {
+	return []ComponentId{ComponentIdFor[A](world)}
+}
+
 func (filter NoFilter) getFilterType() filterType {
 	return filterTypeNone
 }
@@ -68,6 +87,14 @@ func (filter Or[A, B]) getFilterType() filterType {
 	return filterTypeOr
 }
 
+func (filter Added[A]) getFilterType() filterType {
+	return filterTypeAdded
+}
+
+func (filter Changed[A]) getFilterType() filterType {
+	return filterTypeChanged
+}
+
 func (filter NoFilter) getNestedFilters() (a QueryParamFilter, b QueryParamFilter, err error) {
 	return nil, nil, errors.New("nested filters not supported for this type")
 }
@@ -80,6 +107,14 @@ func (filter Without[A]) getNestedFilters() (a QueryParamFilter, b QueryParamFil
 	return nil, nil, errors.New("nested filters not supported for this type")
 }
 
+func (filter Added[A]) getNestedFilters() (a QueryParamFilter, b QueryParamFilter, err error) {
+	return nil, nil, errors.New("nested filters not supported for this type")
+}
+
+func (filter Changed[A]) getNestedFilters() (a QueryParamFilter, b QueryParamFilter, err error) {
+	return nil, nil, errors.New("nested filters not supported for this type")
+}
+
 func (filter And[A, B]) getNestedFilters() (a QueryParamFilter, b QueryParamFilter, err error) {
 	a, err = utils.ToConcrete[A]()
 	if err != nil {
@@ -120,6 +155,12 @@ func (filter And[A, B]) GetCombinedQueryOptions(world *World) (CombinedQueryOpti
 func (filter Or[A, B]) GetCombinedQueryOptions(world *World) (CombinedQueryOptions, error) {
 	return toCombinedQueryOptions[QueryOptions[Or[A, B], NoOptional, NotLazy, DefaultWorld]](world)
 }
+func (filter Added[A]) GetCombinedQueryOptions(world *World) (CombinedQueryOptions, error) {
+	return toCombinedQueryOptions[QueryOptions[Added[A], NoOptional, NotLazy, DefaultWorld]](world)
+}
+func (filter Changed[A]) GetCombinedQueryOptions(world *World) (CombinedQueryOptions, error) {
+	return toCombinedQueryOptions[QueryOptions[Changed[A], NoOptional, NotLazy, DefaultWorld]](world)
+}
 
 type QueryFilter interface {
 	// EntityMeetsCriteria returns false is the entity is filtered out
@@ -127,6 +168,14 @@ type QueryFilter interface {
 
 	// EntityMeetsCriteria returns false is the archetype is filtered out
 	ArchetypeMeetsCriteria(*Archetype) bool
+
+	// rowMeetsCriteria returns false if the entity at row of archetype is filtered out. Components that got
+	// added or changed in between the ticks count as added or changed, see [queryChangeTicks.isNewer]. Only needs to be called if hasChangeFilter
+	// returns true, because otherwise ArchetypeMeetsCriteria decides for all entities of the archetype.
+	rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool
+
+	// hasChangeFilter returns whether the filter depends on the change ticks of components.
+	hasChangeFilter() bool
 }
 type queryFilterAnd struct {
 	a QueryFilter
@@ -142,6 +191,12 @@ type queryFilterWith struct {
 type queryFilterWithout struct {
 	c []ComponentId
 }
+type queryFilterAdded struct {
+	c ComponentId
+}
+type queryFilterChanged struct {
+	c ComponentId
+}
 
 func (filter *queryFilterAnd) EntityMeetsCriteria(e *EntityData) bool {
 	return filter.a.EntityMeetsCriteria(e) && filter.b.EntityMeetsCriteria(e)
@@ -186,3 +241,73 @@ func (filter *queryFilterWith) ArchetypeMeetsCriteria(archetype *Archetype) bool
 func (filter *queryFilterWithout) ArchetypeMeetsCriteria(archetype *Archetype) bool {
 	return !slices.ContainsFunc(filter.c, archetype.HasComponent)
 }
+
+// EntityMeetsCriteria only checks if the entity has the component, because the change tick to compare against
+// is not known.
+func (filter *queryFilterAdded) EntityMeetsCriteria(e *EntityData) bool {
+	return e.hasComponent(filter.c)
+}
+
+// EntityMeetsCriteria only checks if the entity has the component, because the change tick to compare against
+// is not known.
+func (filter *queryFilterChanged) EntityMeetsCriteria(e *EntityData) bool {
+	return e.hasComponent(filter.c)
+}
+
+func (filter *queryFilterAdded) ArchetypeMeetsCriteria(archetype *Archetype) bool {
+	return archetype.HasComponent(filter.c)
+}
+
+func (filter *queryFilterChanged) ArchetypeMeetsCriteria(archetype *Archetype) bool {
+	return archetype.HasComponent(filter.c)
+}
+
+func (filter *queryFilterAnd) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	return filter.a.rowMeetsCriteria(archetype, row, changeTicks) && filter.b.rowMeetsCriteria(archetype, row, changeTicks)
+}
+
+func (filter *queryFilterOr) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	return filter.a.rowMeetsCriteria(archetype, row, changeTicks) || filter.b.rowMeetsCriteria(archetype, row, changeTicks)
+}
+
+func (filter *queryFilterWith) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	return filter.ArchetypeMeetsCriteria(archetype)
+}
+
+func (filter *queryFilterWithout) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	return filter.ArchetypeMeetsCriteria(archetype)
+}
+
+func (filter *queryFilterAdded) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	storage, ok := archetype.components[filter.c]
+	return ok && changeTicks.isNewer(storage.ticks[row].added)
+}
+
+func (filter *queryFilterChanged) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	storage, ok := archetype.components[filter.c]
+	return ok && changeTicks.isNewer(storage.ticks[row].changed)
+}
+
+func (filter *queryFilterAnd) hasChangeFilter() bool {
+	return filter.a.hasChangeFilter() || filter.b.hasChangeFilter()
+}
+
+func (filter *queryFilterOr) hasChangeFilter() bool {
+	return filter.a.hasChangeFilter() || filter.b.hasChangeFilter()
+}
+
+func (filter *queryFilterWith) hasChangeFilter() bool {
+	return false
+}
+
+func (filter *queryFilterWithout) hasChangeFilter() bool {
+	return false
+}
+
+func (filter *queryFilterAdded) hasChangeFilter() bool {
+	return true
+}
+
+func (filter *queryFilterChanged) hasChangeFilter() bool {
+	return true
+}
@@ -16,7 +16,8 @@ func TestQueryFilter(t *testing.T) {
 		world := NewDefaultWorld()
 		entity, err := Spawn(world, &componentA{})
 		assert.NoError(err)
-		entityData := world.entities[entity]
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
 
 		filter := queryFilterWith{c: []ComponentId{
 			ComponentIdFor[componentA](world),
@@ -35,7 +36,8 @@ func TestQueryFilter(t *testing.T) {
 		world := NewDefaultWorld()
 		entity, err := Spawn(world, &componentA{})
 		assert.NoError(err)
-		entityData := world.entities[entity]
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
 
 		filter := queryFilterWithout{c: []ComponentId{
 			ComponentIdFor[componentA](world),
@@ -54,7 +56,8 @@ func TestQueryFilter(t *testing.T) {
 		world := NewDefaultWorld()
 		entity, err := Spawn(world, &componentA{})
 		assert.NoError(err)
-		entityData := world.entities[entity]
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
 
 		// both are true
 		filter := queryFilterAnd{
@@ -96,7 +99,8 @@ func TestQueryFilter(t *testing.T) {
 		world := NewDefaultWorld()
 		entity, err := Spawn(world, &componentA{}, &componentB{})
 		assert.NoError(err)
-		entityData := world.entities[entity]
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
 
 		// both are true
 		filter := queryFilterOr{
@@ -0,0 +1,20 @@
+package ecs
+
+// ZeroCopy makes the query not copy the components of its results when calling Exec. Instead, the
+// components are read directly from the component storages while iterating with Iter, IterUntilErr
+// or Range.
+//
+// This saves the allocations and copies of materializing the query results, but it does not support
+// random access: Single returns an [ErrQueryIsZeroCopy] error. Omit this option if you need it.
+//
+// Iterating visits the entities that are in the matched archetypes at the moment of iterating. Archetypes
+// that got created after calling Exec are not visited.
+//
+// Zero-copy queries can iterate over chunks of components, see [Query1.IterChunks]. Because chunks give write access
+// to the component storages, systems with a zero-copy query are regarded as writing all of its components, so they do
+// not run in parallel with systems that use any of these components.
+type ZeroCopy struct{}
+
+func (ZeroCopy) GetCombinedQueryOptions(world *World) (CombinedQueryOptions, error) {
+	return CombinedQueryOptions{isZeroCopy: true}, nil
+}
@@ -12,6 +12,7 @@ type CombinedQueryOptions struct {
 	Filters            []QueryFilter
 	OptionalComponents []ComponentId
 	isLazy             bool
+	isZeroCopy         bool
 	TargetWorld        *WorldId
 }
 
@@ -25,6 +26,29 @@ func (o *CombinedQueryOptions) isArchetypeFilteredOut(archetype *Archetype) bool
 	return false
 }
 
+// hasChangeFilter returns whether any of the filters depends on the change ticks of components, in which case
+// the filters have to be checked for each entity instead of for each archetype.
+func (o *CombinedQueryOptions) hasChangeFilter() bool {
+	for i := range o.Filters {
+		if o.Filters[i].hasChangeFilter() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rowMeetsCriteria returns whether the entity at row of archetype passes all filters.
+func (o *CombinedQueryOptions) rowMeetsCriteria(archetype *Archetype, row uint, changeTicks queryChangeTicks) bool {
+	for i := range o.Filters {
+		if !o.Filters[i].rowMeetsCriteria(archetype, row, changeTicks) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // validateOptions returns an error if there are any invalid or non-logical options.
 // If an error is returned, it does not mean that the combinedQueryOptions can not be
 // used in a query, thus the error should be treated as a warning.
@@ -102,6 +126,10 @@ func getFilterFromConcreteQueryParamFilter(filters QueryParamFilter, world *Worl
 		return &queryFilterWith{c: filters.getComponents(world)}, nil
 	case filterTypeWithout:
 		return &queryFilterWithout{c: filters.getComponents(world)}, nil
+	case filterTypeAdded:
+		return &queryFilterAdded{c: filters.getComponents(world)[0]}, nil
+	case filterTypeChanged:
+		return &queryFilterChanged{c: filters.getComponents(world)[0]}, nil
 	case filterTypeNone:
 		return nil, nil
 	case filterTypeAnd:
@@ -390,6 +418,10 @@ func mergeQueryOptions(queryOptions []QueryOption, world *World) (result Combine
 			result.isLazy = true
 		}
 
+		if !result.isZeroCopy && options.isZeroCopy {
+			result.isZeroCopy = true
+		}
+
 		if result.TargetWorld == nil && options.TargetWorld != nil {
 			result.TargetWorld = options.TargetWorld
 		}
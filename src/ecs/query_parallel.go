@@ -0,0 +1,999 @@
+package ecs
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Items are split in to more batches than there are workers, so that workers that finish early can pick up
+// the remaining work.
+const parallelBatchesPerWorker = 4
+
+// parallelBatchSize returns the size of the batches that numberOfItems get split in to.
+func parallelBatchSize(numberOfItems int, numberOfWorkers int) int {
+	numberOfBatches := numberOfWorkers * parallelBatchesPerWorker
+	return max(1, (numberOfItems+numberOfBatches-1)/numberOfBatches)
+}
+
+// parallelFor calls f for each batch in [0, numberOfBatches) using at most numberOfWorkers goroutines, and
+// returns once all batches are done.
+func parallelFor(numberOfWorkers int, numberOfBatches int, f func(batch int)) {
+	numberOfWorkers = min(numberOfWorkers, numberOfBatches)
+	if numberOfWorkers <= 1 {
+		for batch := range numberOfBatches {
+			f(batch)
+		}
+		return
+	}
+
+	var nextBatch atomic.Int64
+	var waitGroup sync.WaitGroup
+	for range numberOfWorkers {
+		waitGroup.Go(func() {
+			for {
+				batch := int(nextBatch.Add(1)) - 1
+				if batch >= numberOfBatches {
+					return
+				}
+
+				f(batch)
+			}
+		})
+	}
+	waitGroup.Wait()
+}
+
+// parallelForRange splits [0, numberOfItems) in to batches and calls f for each batch in parallel.
+func parallelForRange(numberOfWorkers int, numberOfItems int, f func(start, end int)) {
+	batchSize := parallelBatchSize(numberOfItems, numberOfWorkers)
+	numberOfBatches := (numberOfItems + batchSize - 1) / batchSize
+
+	parallelFor(numberOfWorkers, numberOfBatches, func(batch int) {
+		start := batch * batchSize
+		f(start, min(start+batchSize, numberOfItems))
+	})
+}
+
+// queryBatch is a range of rows of an archetype that matched a query.
+type queryBatch struct {
+	match *queryArchetypeMatch
+	start int
+	end   int
+}
+
+// parallelForArchetypes splits the rows of the archetypes that matched the query on the last call to Exec in
+// to batches and calls f for each batch in parallel.
+func (o *queryOptions) parallelForArchetypes(numberOfWorkers int, f func(match *queryArchetypeMatch, start, end int)) {
+	batchSize := parallelBatchSize(int(o.numberOfZeroCopyResults()), numberOfWorkers)
+
+	o.parallelBatches = o.parallelBatches[:0]
+	for i := range o.archetypeCache.matches {
+		match := &o.archetypeCache.matches[i]
+		numberOfEntities := len(match.archetype.entities)
+		for start := 0; start < numberOfEntities; start += batchSize {
+			o.parallelBatches = append(o.parallelBatches, queryBatch{
+				match: match,
+				start: start,
+				end:   min(start+batchSize, numberOfEntities),
+			})
+		}
+	}
+
+	parallelFor(numberOfWorkers, len(o.parallelBatches), func(i int) {
+		batch := o.parallelBatches[i]
+		f(batch.match, batch.start, batch.end)
+	})
+}
+
+// numberOfParallelWorkers returns the maximum number of goroutines that parallel query iteration uses.
+func (world *World) numberOfParallelWorkers() int {
+	if world.parallelWorkers > 0 {
+		return world.parallelWorkers
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query0[_]) ParIter(f func(entityId EntityId)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			for row := start; row < end; row++ {
+				f(match.archetype.entities[row])
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(q.entityIds[i])
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query1[A, _]) ParIter(f func(entityId EntityId, a A)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+
+			for row := start; row < end; row++ {
+				f(match.archetype.entities[row], readQueryComponent[A](storageA, row, q.componentInfoA.isPointer))
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(q.entityIds[i], q.componentsA[i])
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query2[A, B, _]) ParIter(f func(entityId EntityId, a A, b B)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(q.entityIds[i], q.componentsA[i], q.componentsB[i])
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query3[A, B, C, _]) ParIter(f func(entityId EntityId, a A, b B, c C)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query4[A, B, C, D, _]) ParIter(f func(entityId EntityId, a A, b B, c C, d D)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query5[A, B, C, D, E, _]) ParIter(f func(entityId EntityId, a A, b B, c C, d D, e E)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query6[A, B, C, D, E, F, _]) ParIter(f func(entityId EntityId, a A, b B, c C, d D, e E, f F)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query7[A, B, C, D, E, F, G, _]) ParIter(f func(entityId EntityId, a A, b B, c C, d D, e E, f F, g G)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query8[A, B, C, D, E, F, G, H, _]) ParIter(f func(entityId EntityId, a A, b B, c C, d D, e E, f F, g G, h H)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query9[A, B, C, D, E, F, G, H, I, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+			storageK := match.storages[10]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+					readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+					q.componentsK[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+			storageK := match.storages[10]
+			storageL := match.storages[11]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+					readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+					readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+					q.componentsK[i],
+					q.componentsL[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+			storageK := match.storages[10]
+			storageL := match.storages[11]
+			storageM := match.storages[12]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+					readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+					readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+					readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+					q.componentsK[i],
+					q.componentsL[i],
+					q.componentsM[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+			storageK := match.storages[10]
+			storageL := match.storages[11]
+			storageM := match.storages[12]
+			storageN := match.storages[13]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+					readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+					readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+					readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+					readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+					q.componentsK[i],
+					q.componentsL[i],
+					q.componentsM[i],
+					q.componentsN[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+			storageK := match.storages[10]
+			storageL := match.storages[11]
+			storageM := match.storages[12]
+			storageN := match.storages[13]
+			storageO := match.storages[14]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+					readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+					readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+					readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+					readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+					readQueryComponent[O](storageO, row, q.componentInfoO.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+					q.componentsK[i],
+					q.componentsL[i],
+					q.componentsM[i],
+					q.componentsN[i],
+					q.componentsO[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
+
+// ParIter executes function f on each entity that the query returned, spread over multiple goroutines. The
+// maximum number of goroutines is decided by [WorldConfigs.ParallelWorkers].
+//
+// f is called concurrently, so it must only modify the components of the entity that it is called with.
+// Just like with Iter, the world is locked for structural changes while iterating.
+func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) ParIter(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P)) {
+	numberOfWorkers := q.world.numberOfParallelWorkers()
+
+	q.world.startQuerying()
+	if q.options.isZeroCopy {
+		q.parallelForArchetypes(numberOfWorkers, func(match *queryArchetypeMatch, start, end int) {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+			storageC := match.storages[2]
+			storageD := match.storages[3]
+			storageE := match.storages[4]
+			storageF := match.storages[5]
+			storageG := match.storages[6]
+			storageH := match.storages[7]
+			storageI := match.storages[8]
+			storageJ := match.storages[9]
+			storageK := match.storages[10]
+			storageL := match.storages[11]
+			storageM := match.storages[12]
+			storageN := match.storages[13]
+			storageO := match.storages[14]
+			storageP := match.storages[15]
+
+			for row := start; row < end; row++ {
+				f(
+					match.archetype.entities[row],
+					readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+					readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+					readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+					readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+					readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+					readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+					readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+					readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+					readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+					readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+					readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+					readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+					readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+					readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+					readQueryComponent[O](storageO, row, q.componentInfoO.isPointer),
+					readQueryComponent[P](storageP, row, q.componentInfoP.isPointer),
+				)
+			}
+		})
+	} else {
+		parallelForRange(numberOfWorkers, len(q.entityIds), func(start, end int) {
+			for i := start; i < end; i++ {
+				f(
+					q.entityIds[i],
+					q.componentsA[i],
+					q.componentsB[i],
+					q.componentsC[i],
+					q.componentsD[i],
+					q.componentsE[i],
+					q.componentsF[i],
+					q.componentsG[i],
+					q.componentsH[i],
+					q.componentsI[i],
+					q.componentsJ[i],
+					q.componentsK[i],
+					q.componentsL[i],
+					q.componentsM[i],
+					q.componentsN[i],
+					q.componentsO[i],
+					q.componentsP[i],
+				)
+			}
+		})
+	}
+	q.world.stopQuerying()
+}
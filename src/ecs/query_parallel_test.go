@@ -0,0 +1,162 @@
+package ecs
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelForRange(t *testing.T) {
+	scenarios := []struct {
+		description     string
+		numberOfWorkers int
+		numberOfItems   int
+	}{
+		{description: "no items", numberOfWorkers: 4, numberOfItems: 0},
+		{description: "less items than workers", numberOfWorkers: 4, numberOfItems: 3},
+		{description: "more items than workers", numberOfWorkers: 4, numberOfItems: 1001},
+		{description: "single worker", numberOfWorkers: 1, numberOfItems: 100},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			visits := make([]atomic.Int32, scenario.numberOfItems)
+			parallelForRange(scenario.numberOfWorkers, scenario.numberOfItems, func(start, end int) {
+				for i := start; i < end; i++ {
+					visits[i].Add(1)
+				}
+			})
+
+			for i := range visits {
+				assert.Equal(int32(1), visits[i].Load())
+			}
+		})
+	}
+}
+
+func TestQueryParIter(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct{ Component }
+
+	setup := func(assert *assert.Assertions) (*World, []EntityId) {
+		configs := DefaultWorldConfigs()
+		configs.ParallelWorkers = 4
+		world, err := NewWorld(configs)
+		assert.NoError(err)
+
+		entities := []EntityId{}
+		for i := range 1000 {
+			var entity EntityId
+			if i%3 == 0 {
+				entity, err = Spawn(&world, &componentA{value: i}, &componentB{})
+			} else {
+				entity, err = Spawn(&world, &componentA{value: i})
+			}
+			assert.NoError(err)
+			entities = append(entities, entity)
+		}
+
+		return &world, entities
+	}
+
+	t.Run("visits every entity once", func(t *testing.T) {
+		assert := assert.New(t)
+		world, entities := setup(assert)
+
+		query := Query1[componentA, Default]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		mutex := sync.Mutex{}
+		visits := map[EntityId]int{}
+		query.ParIter(func(entityId EntityId, a componentA) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			visits[entityId]++
+		})
+
+		assert.Len(visits, len(entities))
+		for _, entity := range entities {
+			assert.Equal(1, visits[entity])
+		}
+	})
+
+	t.Run("visits every entity once with ZeroCopy", func(t *testing.T) {
+		assert := assert.New(t)
+		world, entities := setup(assert)
+
+		query := Query1[componentA, ZeroCopy]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		mutex := sync.Mutex{}
+		visits := map[EntityId]int{}
+		query.ParIter(func(entityId EntityId, a componentA) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			visits[entityId]++
+		})
+
+		assert.Len(visits, len(entities))
+		for _, entity := range entities {
+			assert.Equal(1, visits[entity])
+		}
+	})
+
+	t.Run("can modify pointer components", func(t *testing.T) {
+		assert := assert.New(t)
+		world, entities := setup(assert)
+
+		query := Query1[*componentA, QueryOptions2[With[componentB], ZeroCopy]]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		query.ParIter(func(entityId EntityId, a *componentA) {
+			a.value = -1
+		})
+
+		for i, entity := range entities {
+			a, err := Get1[componentA](world, entity)
+			assert.NoError(err)
+			if i%3 == 0 {
+				assert.Equal(-1, a.value)
+			} else {
+				assert.Equal(i, a.value)
+			}
+		}
+	})
+
+	t.Run("locks the world while iterating", func(t *testing.T) {
+		assert := assert.New(t)
+		world, _ := setup(assert)
+
+		query := Query0[Default]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		var numberOfLockedErrors atomic.Int32
+		query.ParIter(func(entityId EntityId) {
+			if err := Insert(world, entityId, &componentB{}); err == ErrWorldIsLocked {
+				numberOfLockedErrors.Add(1)
+			}
+		})
+
+		assert.Equal(int32(query.NumberOfResult()), numberOfLockedErrors.Load())
+		assert.False(world.isQuerying())
+	})
+}
@@ -0,0 +1,1569 @@
+package ecs
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// readQueryComponent returns the component at row of storage. Returns the zero value of T if storage is nil,
+// which is the case for optional components that are not present in the archetype.
+//
+// row must be smaller than the number of entities in the archetype of storage.
+func readQueryComponent[T AnyComponent](storage *componentStorage, row int, isPointer bool) (result T) {
+	if storage == nil {
+		return result
+	}
+
+	componentPointer := unsafe.Add(storage.pointerToStart, uintptr(row)*storage.componentSize)
+	return componentFromPointer[T](storage, componentPointer, isPointer)
+}
+
+// execZeroCopy updates the matching archetypes of a zero-copy query. Because the components are not copied,
+// all components that are queried as a pointer are marked as changed.
+//
+// Returns an ErrQueryChangeFilterNotSupported error if the query has an [Added] or [Changed] filter, because
+// zero-copy iteration can not skip entities.
+func (o *queryOptions) execZeroCopy(world *World) error {
+	if o.options.hasChangeFilter() {
+		return fmt.Errorf("%w: zero-copy queries", ErrQueryChangeFilterNotSupported)
+	}
+
+	matches := o.getMatchingArchetypes(world)
+	if !o.hasPointerComponents {
+		return nil
+	}
+
+	for i := range matches {
+		for row := range matches[i].archetype.entities {
+			o.markChanged(&matches[i], uint(row))
+		}
+	}
+
+	return nil
+}
+
+// numberOfZeroCopyResults returns the number of entities in the archetypes that matched the query on the last call to Exec.
+func (o *queryOptions) numberOfZeroCopyResults() uint {
+	count := uint(0)
+	for _, match := range o.archetypeCache.matches {
+		count += uint(len(match.archetype.entities))
+	}
+
+	return count
+}
+
+func (q *Query0[_]) iterZeroCopy(f func(EntityId)) {
+	for _, match := range q.archetypeCache.matches {
+		for _, entity := range match.archetype.entities {
+			f(entity)
+		}
+	}
+}
+
+func (q *Query0[_]) iterUntilErrZeroCopy(f func(EntityId) error) error {
+	for _, match := range q.archetypeCache.matches {
+		for _, entity := range match.archetype.entities {
+			if err := f(entity); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query1[A, _]) iterZeroCopy(f func(EntityId, A)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+
+		for row, entity := range match.archetype.entities {
+			f(entity, readQueryComponent[A](storageA, row, q.componentInfoA.isPointer))
+		}
+	}
+}
+
+func (q *Query1[A, _]) iterUntilErrZeroCopy(f func(EntityId, A) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+
+		for row, entity := range match.archetype.entities {
+			if err := f(entity, readQueryComponent[A](storageA, row, q.componentInfoA.isPointer)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query2[A, B, _]) iterZeroCopy(f func(EntityId, A, B)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query2[A, B, _]) iterUntilErrZeroCopy(f func(EntityId, A, B) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query3[A, B, C, _]) iterZeroCopy(f func(EntityId, A, B, C)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query3[A, B, C, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query4[A, B, C, D, _]) iterZeroCopy(f func(EntityId, A, B, C, D)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query4[A, B, C, D, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query5[A, B, C, D, E, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query5[A, B, C, D, E, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query6[A, B, C, D, E, F, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query6[A, B, C, D, E, F, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query7[A, B, C, D, E, F, G, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query7[A, B, C, D, E, F, G, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query8[A, B, C, D, E, F, G, H, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query8[A, B, C, D, E, F, G, H, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query9[A, B, C, D, E, F, G, H, I, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query9[A, B, C, D, E, F, G, H, I, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+		storageN := match.storages[13]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+		storageN := match.storages[13]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+		storageN := match.storages[13]
+		storageO := match.storages[14]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+				readQueryComponent[O](storageO, row, q.componentInfoO.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+		storageN := match.storages[13]
+		storageO := match.storages[14]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+				readQueryComponent[O](storageO, row, q.componentInfoO.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) iterZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P)) {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+		storageN := match.storages[13]
+		storageO := match.storages[14]
+		storageP := match.storages[15]
+
+		for row, entity := range match.archetype.entities {
+			f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+				readQueryComponent[O](storageO, row, q.componentInfoO.isPointer),
+				readQueryComponent[P](storageP, row, q.componentInfoP.isPointer),
+			)
+		}
+	}
+}
+
+func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) iterUntilErrZeroCopy(f func(EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P) error) error {
+	for _, match := range q.archetypeCache.matches {
+		storageA := match.storages[0]
+		storageB := match.storages[1]
+		storageC := match.storages[2]
+		storageD := match.storages[3]
+		storageE := match.storages[4]
+		storageF := match.storages[5]
+		storageG := match.storages[6]
+		storageH := match.storages[7]
+		storageI := match.storages[8]
+		storageJ := match.storages[9]
+		storageK := match.storages[10]
+		storageL := match.storages[11]
+		storageM := match.storages[12]
+		storageN := match.storages[13]
+		storageO := match.storages[14]
+		storageP := match.storages[15]
+
+		for row, entity := range match.archetype.entities {
+			err := f(
+				entity,
+				readQueryComponent[A](storageA, row, q.componentInfoA.isPointer),
+				readQueryComponent[B](storageB, row, q.componentInfoB.isPointer),
+				readQueryComponent[C](storageC, row, q.componentInfoC.isPointer),
+				readQueryComponent[D](storageD, row, q.componentInfoD.isPointer),
+				readQueryComponent[E](storageE, row, q.componentInfoE.isPointer),
+				readQueryComponent[F](storageF, row, q.componentInfoF.isPointer),
+				readQueryComponent[G](storageG, row, q.componentInfoG.isPointer),
+				readQueryComponent[H](storageH, row, q.componentInfoH.isPointer),
+				readQueryComponent[I](storageI, row, q.componentInfoI.isPointer),
+				readQueryComponent[J](storageJ, row, q.componentInfoJ.isPointer),
+				readQueryComponent[K](storageK, row, q.componentInfoK.isPointer),
+				readQueryComponent[L](storageL, row, q.componentInfoL.isPointer),
+				readQueryComponent[M](storageM, row, q.componentInfoM.isPointer),
+				readQueryComponent[N](storageN, row, q.componentInfoN.isPointer),
+				readQueryComponent[O](storageO, row, q.componentInfoO.isPointer),
+				readQueryComponent[P](storageP, row, q.componentInfoP.isPointer),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query0[_]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query0Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query0[_]) Single() (EntityId, error) {
+	if q.options.isZeroCopy {
+		return nonExistingEntity, ErrQueryIsZeroCopy
+	}
+
+	return q.Query0Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query1[A, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query1Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query1[A, _]) Single() (EntityId, A, error) {
+	if q.options.isZeroCopy {
+		var a A
+		return nonExistingEntity, a, ErrQueryIsZeroCopy
+	}
+
+	return q.Query1Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query2[A, B, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query2Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query2[A, B, _]) Single() (EntityId, A, B, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		return nonExistingEntity, a, b, ErrQueryIsZeroCopy
+	}
+
+	return q.Query2Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query3[A, B, C, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query3Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query3[A, B, C, _]) Single() (EntityId, A, B, C, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		return nonExistingEntity, a, b, c, ErrQueryIsZeroCopy
+	}
+
+	return q.Query3Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query4[A, B, C, D, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query4Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query4[A, B, C, D, _]) Single() (EntityId, A, B, C, D, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		return nonExistingEntity, a, b, c, d, ErrQueryIsZeroCopy
+	}
+
+	return q.Query4Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query5[A, B, C, D, E, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query5Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query5[A, B, C, D, E, _]) Single() (EntityId, A, B, C, D, E, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		return nonExistingEntity, a, b, c, d, e, ErrQueryIsZeroCopy
+	}
+
+	return q.Query5Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query6[A, B, C, D, E, F, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query6Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query6[A, B, C, D, E, F, _]) Single() (EntityId, A, B, C, D, E, F, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		return nonExistingEntity, a, b, c, d, e, f, ErrQueryIsZeroCopy
+	}
+
+	return q.Query6Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query7[A, B, C, D, E, F, G, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query7Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query7[A, B, C, D, E, F, G, _]) Single() (EntityId, A, B, C, D, E, F, G, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		return nonExistingEntity, a, b, c, d, e, f, g, ErrQueryIsZeroCopy
+	}
+
+	return q.Query7Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query8[A, B, C, D, E, F, G, H, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query8Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query8[A, B, C, D, E, F, G, H, _]) Single() (EntityId, A, B, C, D, E, F, G, H, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		return nonExistingEntity, a, b, c, d, e, f, g, h, ErrQueryIsZeroCopy
+	}
+
+	return q.Query8Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query9[A, B, C, D, E, F, G, H, I, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query9Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query9[A, B, C, D, E, F, G, H, I, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, ErrQueryIsZeroCopy
+	}
+
+	return q.Query9Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query10Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query10[A, B, C, D, E, F, G, H, I, J, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, ErrQueryIsZeroCopy
+	}
+
+	return q.Query10Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query11Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query11[A, B, C, D, E, F, G, H, I, J, K, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, K, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		var k K
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, k, ErrQueryIsZeroCopy
+	}
+
+	return q.Query11Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query12Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query12[A, B, C, D, E, F, G, H, I, J, K, L, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, K, L, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		var k K
+		var l L
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, k, l, ErrQueryIsZeroCopy
+	}
+
+	return q.Query12Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query13Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query13[A, B, C, D, E, F, G, H, I, J, K, L, M, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		var k K
+		var l L
+		var m M
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, k, l, m, ErrQueryIsZeroCopy
+	}
+
+	return q.Query13Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query14Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query14[A, B, C, D, E, F, G, H, I, J, K, L, M, N, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		var k K
+		var l L
+		var m M
+		var n N
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, k, l, m, n, ErrQueryIsZeroCopy
+	}
+
+	return q.Query14Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query15Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query15[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		var k K
+		var l L
+		var m M
+		var n N
+		var o O
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, ErrQueryIsZeroCopy
+	}
+
+	return q.Query15Result.Single()
+}
+
+// NumberOfResult returns the number of entities that the query returned.
+func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) NumberOfResult() uint {
+	if q.options.isZeroCopy {
+		return q.numberOfZeroCopyResults()
+	}
+
+	return q.Query16Result.NumberOfResult()
+}
+
+// Single returns the only query result, or an [ErrUnexpectedNumberOfQueryResults] error if there is not exactly 1 result.
+//
+// Returns an [ErrQueryIsZeroCopy] error if the query uses the [ZeroCopy] option.
+func (q *Query16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, _]) Single() (EntityId, A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, error) {
+	if q.options.isZeroCopy {
+		var a A
+		var b B
+		var c C
+		var d D
+		var e E
+		var f F
+		var g G
+		var h H
+		var i I
+		var j J
+		var k K
+		var l L
+		var m M
+		var n N
+		var o O
+		var p P
+		return nonExistingEntity, a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, ErrQueryIsZeroCopy
+	}
+
+	return q.Query16Result.Single()
+}
+
+// Range lets you range over the query result
+//
+// for component := range query.Range() { ... }
+func (q *Query1[A, _]) Range() func(yield func(A) bool) {
+	if !q.options.isZeroCopy {
+		return q.Query1Result.Range()
+	}
+
+	return func(yield func(A) bool) {
+		q.world.startQuerying()
+		defer q.world.stopQuerying()
+
+		for _, match := range q.archetypeCache.matches {
+			storageA := match.storages[0]
+
+			for row := range match.archetype.entities {
+				if !yield(readQueryComponent[A](storageA, row, q.componentInfoA.isPointer)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Range lets you range over the query result
+//
+// for a, b := range query.Range() { ... }
+func (q *Query2[A, B, _]) Range() func(yield func(A, B) bool) {
+	if !q.options.isZeroCopy {
+		return q.Query2Result.Range()
+	}
+
+	return func(yield func(A, B) bool) {
+		q.world.startQuerying()
+		defer q.world.stopQuerying()
+
+		for _, match := range q.archetypeCache.matches {
+			storageA := match.storages[0]
+			storageB := match.storages[1]
+
+			for row := range match.archetype.entities {
+				a := readQueryComponent[A](storageA, row, q.componentInfoA.isPointer)
+				b := readQueryComponent[B](storageB, row, q.componentInfoB.isPointer)
+				if !yield(a, b) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package ecs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZeroCopyQuery(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct {
+		Component
+		value int
+	}
+	type componentC struct{ Component }
+
+	t.Run("Exec does not materialize the results", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		assert.Empty(query.entityIds)
+		assert.Empty(query.componentsA)
+		assert.Equal(uint(2), query.NumberOfResult())
+	})
+
+	t.Run("Iter reads the components from the component storages", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		entity1, err := Spawn(world, &componentA{value: 1}, &componentB{value: 10})
+		assert.NoError(err)
+		entity2, err := Spawn(world, &componentA{value: 2}, &componentB{value: 20}, &componentC{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{value: 3})
+		assert.NoError(err)
+
+		query := Query2[componentA, *componentB, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		results := map[EntityId]int{}
+		query.Iter(func(entityId EntityId, a componentA, b *componentB) {
+			assert.True(world.isQuerying())
+			results[entityId] = a.value + b.value
+			b.value = 0
+		})
+		assert.False(world.isQuerying())
+		assert.Equal(map[EntityId]int{entity1: 11, entity2: 22}, results)
+
+		b, err := Get1[componentB](world, entity1)
+		assert.NoError(err)
+		assert.Equal(0, b.value)
+	})
+
+	t.Run("optional components that are not present are nil", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{value: 1})
+		assert.NoError(err)
+
+		query := Query2[componentA, *componentB, QueryOptions2[Optional1[componentB], ZeroCopy]]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		numberOfResults := 0
+		query.Iter(func(entityId EntityId, a componentA, b *componentB) {
+			numberOfResults++
+			assert.Equal(1, a.value)
+			assert.Nil(b)
+		})
+		assert.Equal(1, numberOfResults)
+	})
+
+	t.Run("respects filters", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{}, &componentC{})
+		assert.NoError(err)
+		expectedEntity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query0[QueryOptions2[Without[componentC], ZeroCopy]]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		results := []EntityId{}
+		query.Iter(func(entityId EntityId) {
+			results = append(results, entityId)
+		})
+		assert.Equal([]EntityId{expectedEntity}, results)
+	})
+
+	t.Run("IterUntilErr stops at the first error", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		for range 3 {
+			_, err := Spawn(world, &componentA{})
+			assert.NoError(err)
+		}
+
+		query := Query1[componentA, ZeroCopy]{}
+		err := query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		expectedErr := errors.New("stop")
+		numberOfCalls := 0
+		err = query.IterUntilErr(func(entityId EntityId, a componentA) error {
+			numberOfCalls++
+			return expectedErr
+		})
+		assert.ErrorIs(err, expectedErr)
+		assert.Equal(1, numberOfCalls)
+		assert.False(world.isQuerying())
+	})
+
+	t.Run("Range yields the components", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{value: 1}, &componentB{value: 2})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{value: 3}, &componentB{value: 4}, &componentC{})
+		assert.NoError(err)
+
+		query := Query2[componentA, componentB, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		sum := 0
+		for a, b := range query.Range() {
+			sum += a.value + b.value
+			assert.True(world.isQuerying())
+		}
+		assert.Equal(10, sum)
+		assert.False(world.isQuerying())
+	})
+
+	t.Run("Range does not allow structural changes while ranging", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		for range query.Range() {
+			_, err = Spawn(world, &componentA{})
+			assert.ErrorIs(err, ErrWorldIsLocked)
+			break
+		}
+		assert.False(world.isQuerying())
+
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+	})
+
+	t.Run("Single returns an error", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		query := Query1[componentA, ZeroCopy]{}
+		err = query.Prepare(world, nil)
+		assert.NoError(err)
+		err = query.Exec(world)
+		assert.NoError(err)
+
+		_, _, err = query.Single()
+		assert.ErrorIs(err, ErrQueryIsZeroCopy)
+	})
+
+	t.Run("gives the same results as the materialized query", func(t *testing.T) {
+		assert := assert.New(t)
+
+		world := NewDefaultWorld()
+		for i := range 10 {
+			if i%2 == 0 {
+				_, err := Spawn(world, &componentA{value: i}, &componentC{})
+				assert.NoError(err)
+			} else {
+				_, err := Spawn(world, &componentA{value: i}, &componentB{value: i})
+				assert.NoError(err)
+			}
+		}
+
+		materializedQuery := Query2[componentA, componentB, Optional1[componentB]]{}
+		err := materializedQuery.Prepare(world, nil)
+		assert.NoError(err)
+		err = materializedQuery.Exec(world)
+		assert.NoError(err)
+
+		zeroCopyQuery := Query2[componentA, componentB, QueryOptions2[Optional1[componentB], ZeroCopy]]{}
+		err = zeroCopyQuery.Prepare(world, nil)
+		assert.NoError(err)
+		err = zeroCopyQuery.Exec(world)
+		assert.NoError(err)
+
+		expected := map[EntityId]componentB{}
+		materializedQuery.Iter(func(entityId EntityId, a componentA, b componentB) {
+			expected[entityId] = b
+		})
+		result := map[EntityId]componentB{}
+		zeroCopyQuery.Iter(func(entityId EntityId, a componentA, b componentB) {
+			result[entityId] = b
+		})
+
+		assert.Equal(materializedQuery.NumberOfResult(), zeroCopyQuery.NumberOfResult())
+		assert.Equal(expected, result)
+	})
+}
@@ -0,0 +1,198 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// AnyRelation is a component that relates an entity to a target entity. See [Relation].
+type AnyRelation interface {
+	AnyComponent
+	isRelation()
+}
+
+// Relation can be embedded in to a struct to make a relation type, such as:
+//
+//	type Targets struct {
+//		ecs.Relation
+//		Damage int
+//	}
+//
+// A relation between an entity (the source) and a target entity is stored as a component with the [ComponentId]
+// of the pair (relation type, target), so that an entity can have the same relation with multiple targets. Use
+// [InsertRelation] to add a relation, [RemoveRelation] to remove it and [RelationSources] and [RelationTargets]
+// to query relations from either side.
+//
+// Relations can not be added with [Spawn], [Insert] or [InsertOrOverwrite], and they are not matched by queries
+// of the relation type. Relations are removed automatically when either the source or the target gets despawned
+// with [Despawn].
+type Relation struct{ Component }
+
+func (Relation) isRelation() {}
+
+// RelationIdFor returns the component id of the pair (R, target).
+func RelationIdFor[R AnyRelation](world *World, target EntityId) ComponentId {
+	componentId := ComponentIdFor[R](world)
+	componentId.target = target
+	return componentId
+}
+
+// InsertRelation adds relation R from entity to target.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error when entity does not exist
+//   - Returns an ErrEntityStale error when entity has been despawned
+//   - Returns an ErrRelationTargetNotFound error when target does not exist
+//   - Returns an ErrComponentIsNil error when relation is nil
+//   - Returns an ErrComponentAlreadyPresent error when entity already has relation R with target
+//   - Returns an ErrWorldIsLocked error while querying
+func InsertRelation[R AnyRelation](world *World, entity EntityId, target EntityId, relation R) error {
+	if world.isQuerying() {
+		// We can not allow this ecs operation while querying because archetype moves
+		// will mess with the query results.
+		return ErrWorldIsLocked
+	}
+
+	if reflect.ValueOf(relation).Kind() == reflect.Pointer && reflect.ValueOf(relation).IsNil() {
+		return ErrComponentIsNil
+	}
+
+	if _, err := world.entities.get(target); err != nil {
+		return fmt.Errorf("%w: %w", ErrRelationTargetNotFound, err)
+	}
+
+	return insert(world, entity, []AnyComponent{relation}, []ComponentId{RelationIdFor[R](world, target)})
+}
+
+// RemoveRelation removes relation R from entity to target.
+//
+// Can return the following errors:
+//   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
+//   - ErrComponentNotFound error if the entity does not have relation R with target.
+//   - ErrWorldIsLocked error while querying
+func RemoveRelation[R AnyRelation](world *World, entity EntityId, target EntityId) error {
+	if world.isQuerying() {
+		// Prevent archetype moves during querying to prevent unexpected behavior.
+		return ErrWorldIsLocked
+	}
+
+	return removeComponents(world, entity, []ComponentId{RelationIdFor[R](world, target)})
+}
+
+// HasRelation returns whether entity has relation R with target.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
+func HasRelation[R AnyRelation](world *World, entity EntityId, target EntityId) (bool, error) {
+	return HasComponentId(world, entity, RelationIdFor[R](world, target))
+}
+
+// GetRelation returns relation R from entity to target.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
+//   - Returns an ErrComponentNotFound error if the entity does not have relation R with target.
+//
+// WARNING: Do not store the relation pointer
+func GetRelation[R AnyRelation](world *World, entity EntityId, target EntityId) (result R, err error) {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return result, err
+	}
+
+	relationId := RelationIdFor[R](world, target)
+	storage, exists := entityData.archetype.components[relationId]
+	if !exists {
+		return result, fmt.Errorf("%w: %s", ErrComponentNotFound, relationId.DebugString())
+	}
+
+	return getComponentFromComponentStorage[R](storage, entityData.row, reflect.TypeFor[R]().Kind() == reflect.Pointer)
+}
+
+// RelationTargets returns the entities that entity has relation R with.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the entity is not found.
+//   - Returns an ErrEntityStale error if the entity has been despawned.
+func RelationTargets[R AnyRelation](world *World, entity EntityId) ([]EntityId, error) {
+	entityData, err := world.entities.get(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	relationId := ComponentIdFor[R](world)
+
+	result := []EntityId{}
+	for _, componentId := range entityData.archetype.componentIds {
+		if target, isRelation := componentId.Target(); isRelation && componentId.id == relationId.id {
+			result = append(result, target)
+		}
+	}
+
+	return result, nil
+}
+
+// RelationSources returns the entities that have relation R with target. Only the archetypes that contain the
+// pair (R, target) are visited.
+//
+// Can return the following errors:
+//   - Returns an ErrEntityNotFound error if the target is not found.
+//   - Returns an ErrEntityStale error if the target has been despawned.
+func RelationSources[R AnyRelation](world *World, target EntityId) ([]EntityId, error) {
+	if _, err := world.entities.get(target); err != nil {
+		return nil, err
+	}
+
+	result := []EntityId{}
+
+	archetypes, exists := world.archetypeStorage.componentIdToArchetypes[RelationIdFor[R](world, target)]
+	if !exists {
+		return result, nil
+	}
+
+	for _, archetype := range *archetypes {
+		result = append(result, archetype.entities...)
+	}
+
+	return result, nil
+}
+
+// removeRelationsTo removes all relations that target the given entity, which should be called when target got
+// despawned.
+func removeRelationsTo(world *World, target EntityId) error {
+	relationIds, exists := world.archetypeStorage.relationIdsByTarget[target]
+	if !exists {
+		return nil
+	}
+	delete(world.archetypeStorage.relationIdsByTarget, target)
+
+	var resultErr error
+	for _, relationId := range relationIds {
+		archetypes, exists := world.archetypeStorage.componentIdToArchetypes[relationId]
+		if !exists {
+			continue
+		}
+
+		sources := []EntityId{}
+		for _, archetype := range *archetypes {
+			sources = append(sources, archetype.entities...)
+		}
+
+		for _, source := range sources {
+			err := removeComponents(world, source, []ComponentId{relationId})
+			if err != nil {
+				resultErr = errors.Join(resultErr, fmt.Errorf("failed to remove relation %s from entity %s: %w", relationId.DebugString(), source, err))
+			}
+		}
+
+		// The pair can never be added again because target no longer exists, so its archetypes would otherwise
+		// be kept forever.
+		world.archetypeStorage.removeEmptyArchetypesWith(relationId)
+	}
+
+	return resultErr
+}
@@ -0,0 +1,213 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/lucdrenth/murphecs/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelation(t *testing.T) {
+	type targets struct {
+		Relation
+		damage int
+	}
+	type ownedBy struct{ Relation }
+	type componentA struct{ Component }
+
+	t.Run("relation pairs with different targets have different hashes", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		targetA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		targetB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		hashes := []string{
+			hashComponentIds([]ComponentId{ComponentIdFor[targets](world)}),
+			hashComponentIds([]ComponentId{RelationIdFor[targets](world, targetA)}),
+			hashComponentIds([]ComponentId{RelationIdFor[targets](world, targetB)}),
+			hashComponentIds([]ComponentId{RelationIdFor[ownedBy](world, targetA)}),
+			hashComponentIds([]ComponentId{RelationIdFor[targets](world, targetA), RelationIdFor[targets](world, targetB)}),
+		}
+		assert.True(utils.IsUnique(hashes))
+	})
+
+	t.Run("can be queried from both sides", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		targetA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		targetB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		sourceA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		sourceB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		assert.NoError(InsertRelation(world, sourceA, targetA, &targets{damage: 1}))
+		assert.NoError(InsertRelation(world, sourceA, targetB, targets{damage: 2}))
+		assert.NoError(InsertRelation(world, sourceB, targetA, targets{damage: 3}))
+		assert.NoError(InsertRelation(world, sourceB, targetB, ownedBy{}))
+
+		sources, err := RelationSources[targets](world, targetA)
+		assert.NoError(err)
+		assert.ElementsMatch([]EntityId{sourceA, sourceB}, sources)
+
+		sources, err = RelationSources[targets](world, targetB)
+		assert.NoError(err)
+		assert.Equal([]EntityId{sourceA}, sources)
+
+		relationTargets, err := RelationTargets[targets](world, sourceA)
+		assert.NoError(err)
+		assert.ElementsMatch([]EntityId{targetA, targetB}, relationTargets)
+
+		relationTargets, err = RelationTargets[ownedBy](world, sourceB)
+		assert.NoError(err)
+		assert.Equal([]EntityId{targetB}, relationTargets)
+
+		relation, err := GetRelation[targets](world, sourceA, targetB)
+		assert.NoError(err)
+		assert.Equal(2, relation.damage)
+
+		hasRelation, err := HasRelation[ownedBy](world, sourceA, targetB)
+		assert.NoError(err)
+		assert.False(hasRelation)
+
+		_, err = GetRelation[ownedBy](world, sourceA, targetB)
+		assert.ErrorIs(err, ErrComponentNotFound)
+	})
+
+	t.Run("InsertRelation returns an error if the target does not exist or the relation is already present", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		assert.ErrorIs(InsertRelation(world, entity, nonExistingEntity, targets{}), ErrRelationTargetNotFound)
+		assert.NoError(InsertRelation(world, entity, entity, targets{}))
+		assert.ErrorIs(InsertRelation(world, entity, entity, targets{}), ErrComponentAlreadyPresent)
+		assert.ErrorIs(InsertRelation[*targets](world, entity, entity, nil), ErrComponentIsNil)
+	})
+
+	t.Run("relations can not be added as a regular component", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Spawn(world, &targets{})
+		assert.ErrorIs(err, ErrComponentIsRelation)
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.ErrorIs(Insert(world, entity, &targets{}), ErrComponentIsRelation)
+		assert.ErrorIs(InsertOrOverwrite(world, entity, &targets{}), ErrComponentIsRelation)
+	})
+
+	t.Run("RemoveRelation removes a single pair", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		targetA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		targetB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		source, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		assert.NoError(InsertRelation(world, source, targetA, targets{}))
+		assert.NoError(InsertRelation(world, source, targetB, targets{}))
+		assert.NoError(RemoveRelation[targets](world, source, targetA))
+		assert.ErrorIs(RemoveRelation[targets](world, source, targetA), ErrComponentNotFound)
+
+		relationTargets, err := RelationTargets[targets](world, source)
+		assert.NoError(err)
+		assert.Equal([]EntityId{targetB}, relationTargets)
+	})
+
+	t.Run("relations are removed when the target gets despawned", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		target, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		otherTarget, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		sourceA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		sourceB, err := Spawn(world)
+		assert.NoError(err)
+
+		assert.NoError(InsertRelation(world, sourceA, target, targets{}))
+		assert.NoError(InsertRelation(world, sourceA, otherTarget, targets{}))
+		assert.NoError(InsertRelation(world, sourceB, target, ownedBy{}))
+
+		assert.NoError(Despawn(world, target))
+
+		relationTargets, err := RelationTargets[targets](world, sourceA)
+		assert.NoError(err)
+		assert.Equal([]EntityId{otherTarget}, relationTargets)
+
+		relationTargets, err = RelationTargets[ownedBy](world, sourceB)
+		assert.NoError(err)
+		assert.Empty(relationTargets)
+		assert.Empty(world.archetypeStorage.relationIdsByTarget[target])
+	})
+
+	t.Run("archetypes of relation pairs are removed when the target gets despawned", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		query := Query1[componentA, Default]{}
+		assert.NoError(query.Prepare(world, nil))
+
+		otherTarget, err := Spawn(world)
+		assert.NoError(err)
+		source, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, source, otherTarget, ownedBy{}))
+
+		assert.NoError(query.Exec(world))
+		numberOfArchetypes := world.CountArchetypes()
+		numberOfMatches := len(query.archetypeCache.matches)
+
+		for range 1000 {
+			target, err := Spawn(world)
+			assert.NoError(err)
+			assert.NoError(InsertRelation(world, source, target, targets{}))
+			assert.NoError(query.Exec(world))
+			assert.Equal(uint(1), query.NumberOfResult())
+
+			assert.NoError(Despawn(world, target))
+			assert.NoError(query.Exec(world))
+			assert.Equal(uint(1), query.NumberOfResult())
+		}
+
+		assert.Equal(numberOfArchetypes, world.CountArchetypes())
+		assert.Len(world.archetypeStorage.archetypes, numberOfArchetypes)
+		assert.Len(query.archetypeCache.matches, numberOfMatches)
+
+		relationTargets, err := RelationTargets[ownedBy](world, source)
+		assert.NoError(err)
+		assert.Equal([]EntityId{otherTarget}, relationTargets)
+	})
+
+	t.Run("relations are removed when the source gets despawned", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		target, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		source, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		assert.NoError(InsertRelation(world, source, target, targets{}))
+		assert.NoError(Despawn(world, source))
+
+		sources, err := RelationSources[targets](world, target)
+		assert.NoError(err)
+		assert.Empty(sources)
+	})
+}
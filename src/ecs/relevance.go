@@ -0,0 +1,165 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/lucdrenth/murphecs/src/utils"
+)
+
+// Relevance decides which entities are relevant to a [DeltaTracker], such as the entities that are replicated to a
+// single client. Entities that become relevant show up as spawned in the deltas of the tracker, and entities that are
+// no longer relevant show up as despawned.
+//
+// An entity is relevant if it passes the filter of the relevance and all of its predicates.
+type Relevance struct {
+	filter     QueryFilter // nil if all entities pass the filter
+	predicates []RelevancePredicate
+}
+
+// RelevancePredicate decides for a single entity whether it is relevant, see [NewRelevance]. Use [RelevantIf] or
+// [WithinRadius] to create one.
+type RelevancePredicate interface {
+	// prepare is called once each time the relevance of the entities of world is decided.
+	prepare(world *World)
+
+	// isRelevant returns whether entity, which is at row of archetype, is relevant.
+	isRelevant(world *World, archetype *Archetype, row uint, entity EntityId) bool
+}
+
+// NewRelevance returns a relevance that includes the entities that pass filter F and all predicates, such as:
+//
+//	relevance, err := ecs.NewRelevance[ecs.Without[Hidden]](world, ecs.WithinRadius[Position](avatar, 50))
+//
+// F can be any combination of [With], [Without], [And], [Or] and [NoFilter], just like the filter of a query.
+//
+// Can return the following errors:
+//   - Returns an ErrQueryChangeFilterNotSupported error when F contains [Added] or [Changed]
+//   - Returns an error when F is not a valid filter
+func NewRelevance[F QueryParamFilter](world *World, predicates ...RelevancePredicate) (*Relevance, error) {
+	concreteFilter, err := utils.ToConcrete[F]()
+	if err != nil {
+		return nil, fmt.Errorf("failed to cast filter to concrete type: %w", err)
+	}
+
+	filter, err := getFilterFromConcreteQueryParamFilter(concreteFilter, world)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filter: %w", err)
+	}
+
+	if filter != nil && filter.hasChangeFilter() {
+		// Relevance is decided outside of systems, so there is no previous run to compare the change ticks with.
+		return nil, ErrQueryChangeFilterNotSupported
+	}
+
+	return &Relevance{
+		filter:     filter,
+		predicates: predicates,
+	}, nil
+}
+
+func (relevance *Relevance) prepare(world *World) {
+	for _, predicate := range relevance.predicates {
+		predicate.prepare(world)
+	}
+}
+
+// archetypeIsRelevant returns whether the entities of archetype can be relevant. If it returns true, the predicates
+// still have to be checked with [Relevance.rowIsRelevant].
+func (relevance *Relevance) archetypeIsRelevant(archetype *Archetype) bool {
+	return relevance.filter == nil || relevance.filter.ArchetypeMeetsCriteria(archetype)
+}
+
+func (relevance *Relevance) rowIsRelevant(world *World, archetype *Archetype, row uint, entity EntityId) bool {
+	for _, predicate := range relevance.predicates {
+		if !predicate.isRelevant(world, archetype, row, entity) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type relevantIf struct {
+	predicate func(world *World, entity EntityId) bool
+}
+
+// RelevantIf returns a predicate that makes the entities for which predicate returns true relevant, see
+// [NewRelevance]. predicate must not change world.
+func RelevantIf(predicate func(world *World, entity EntityId) bool) RelevancePredicate {
+	return &relevantIf{predicate: predicate}
+}
+
+func (relevant *relevantIf) prepare(world *World) {}
+
+func (relevant *relevantIf) isRelevant(world *World, archetype *Archetype, row uint, entity EntityId) bool {
+	return relevant.predicate(world, entity)
+}
+
+// Positioned is a component that has a position, see [WithinRadius].
+type Positioned interface {
+	AnyComponent
+	Position() (x, y, z float64)
+}
+
+type withinRadius[P Positioned] struct {
+	center EntityId
+	radius float64
+
+	// set by prepare
+	componentId    ComponentId
+	isPointer      bool // whether P is a pointer to the component type, such as *Position
+	centerPosition [3]float64
+	hasCenter      bool
+}
+
+// WithinRadius returns a predicate that makes the entities of which component P is within radius of component P of
+// center relevant, see [NewRelevance]. Entities that do not have component P are relevant as well, use [With] to
+// leave them out. No entity with component P is relevant if center does not exist or does not have component P.
+//
+// P is a pointer to the component, such as *Position, when Position implements Position with a pointer receiver.
+func WithinRadius[P Positioned](center EntityId, radius float64) RelevancePredicate {
+	return &withinRadius[P]{
+		center: center,
+		radius: radius,
+	}
+}
+
+func (within *withinRadius[P]) prepare(world *World) {
+	within.componentId = ComponentIdFor[P](world)
+	within.isPointer = reflect.TypeFor[P]().Kind() == reflect.Pointer
+
+	center, err := Get1[P](world, within.center)
+	within.hasCenter = err == nil
+	if within.hasCenter {
+		x, y, z := center.Position()
+		within.centerPosition = [3]float64{x, y, z}
+	}
+}
+
+func (within *withinRadius[P]) isRelevant(world *World, archetype *Archetype, row uint, entity EntityId) bool {
+	storage, hasPosition := archetype.components[within.componentId]
+	if !hasPosition {
+		return true
+	}
+	if !within.hasCenter {
+		return false
+	}
+
+	pointer, err := storage.getComponentPointer(row)
+	if err != nil {
+		return false
+	}
+
+	// Component storages hold the component type itself, so a pointer P has to point in to the storage.
+	var position P
+	if within.isPointer {
+		position = reflect.NewAt(within.componentId.componentType, pointer).Interface().(P)
+	} else {
+		position = *(*P)(pointer)
+	}
+
+	x, y, z := position.Position()
+	dx, dy, dz := x-within.centerPosition[0], y-within.centerPosition[1], z-within.centerPosition[2]
+	return dx*dx+dy*dy+dz*dz <= within.radius*within.radius
+}
@@ -0,0 +1,212 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type relevancePosition struct {
+	Component
+	X, Y float64
+}
+
+func (position relevancePosition) Position() (x, y, z float64) {
+	return position.X, position.Y, 0
+}
+
+type relevancePointerPosition struct {
+	Component
+	X float64
+}
+
+func (position *relevancePointerPosition) Position() (x, y, z float64) {
+	return position.X, 0, 0
+}
+
+func TestRelevance(t *testing.T) {
+	type relevanceHidden struct{ Component }
+	type relevanceScore struct {
+		Component
+		Points int
+	}
+
+	t.Run("entities that pass the filter are relevant", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		relevance, err := NewRelevance[Without[relevanceHidden]](world)
+		assert.NoError(err)
+		tracker := NewDeltaTracker(nil)
+		tracker.SetRelevance(relevance)
+
+		visible, err := Spawn(world, &relevanceScore{})
+		assert.NoError(err)
+		_, err = Spawn(world, &relevanceScore{}, &relevanceHidden{})
+		assert.NoError(err)
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{visible}, delta.Spawned)
+
+		// entities that are no longer relevant show up as despawned, and as spawned when they are relevant again
+		assert.NoError(Insert(world, visible, &relevanceHidden{}))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{visible}, delta.Despawned)
+
+		assert.NoError(Remove1[relevanceHidden](world, visible))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{visible}, delta.Spawned)
+	})
+
+	t.Run("relations to entities that are not relevant are left out", func(t *testing.T) {
+		type relevanceFollows struct{ Relation }
+
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		replica := NewDefaultWorld()
+		RegisterComponent[relevanceScore](replica)
+		RegisterComponent[relevanceHidden](replica)
+		RegisterComponent[relevanceFollows](replica)
+		entityMap := map[EntityId]EntityId{}
+
+		relevance, err := NewRelevance[Without[relevanceHidden]](world)
+		assert.NoError(err)
+		tracker := NewDeltaTracker(nil)
+		tracker.SetRelevance(relevance)
+
+		source, err := Spawn(world, &relevanceScore{})
+		assert.NoError(err)
+		target, err := Spawn(world, &relevanceScore{})
+		assert.NoError(err)
+		hidden, err := Spawn(world, &relevanceScore{}, &relevanceHidden{})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, source, target, relevanceFollows{}))
+		assert.NoError(InsertRelation(world, source, hidden, relevanceFollows{}))
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{source, target}, delta.Spawned)
+		assert.NoError(ApplyDelta(replica, delta, entityMap))
+		targets, err := RelationTargets[relevanceFollows](replica, entityMap[source])
+		assert.NoError(err)
+		assert.Equal([]EntityId{entityMap[target]}, targets)
+
+		// a target that is no longer relevant removes the relation, and adds it again when it is relevant again
+		assert.NoError(Insert(world, target, &relevanceHidden{}))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{target}, delta.Despawned)
+		assert.Len(delta.Entities, 1)
+		assert.Len(delta.Entities[0].Removed, 1)
+		assert.NoError(ApplyDelta(replica, delta, entityMap))
+		targets, err = RelationTargets[relevanceFollows](replica, entityMap[source])
+		assert.NoError(err)
+		assert.Empty(targets)
+
+		assert.NoError(Remove1[relevanceHidden](world, target))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{target}, delta.Spawned)
+		assert.NoError(ApplyDelta(replica, delta, entityMap))
+		targets, err = RelationTargets[relevanceFollows](replica, entityMap[source])
+		assert.NoError(err)
+		assert.Equal([]EntityId{entityMap[target]}, targets)
+	})
+
+	t.Run("entities for which the predicate returns true are relevant", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		relevance, err := NewRelevance[With[relevanceScore]](world, RelevantIf(func(world *World, entity EntityId) bool {
+			score, err := Get1[relevanceScore](world, entity)
+			return err == nil && score.Points > 10
+		}))
+		assert.NoError(err)
+		tracker := NewDeltaTracker(nil)
+		tracker.SetRelevance(relevance)
+
+		high, err := Spawn(world, &relevanceScore{Points: 20})
+		assert.NoError(err)
+		_, err = Spawn(world, &relevanceScore{Points: 5})
+		assert.NoError(err)
+		_, err = Spawn(world)
+		assert.NoError(err)
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{high}, delta.Spawned)
+	})
+
+	t.Run("entities within the radius of the center are relevant", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		avatar, err := Spawn(world, &relevancePosition{X: 10, Y: 10})
+		assert.NoError(err)
+		near, err := Spawn(world, &relevancePosition{X: 13, Y: 14})
+		assert.NoError(err)
+		far, err := Spawn(world, &relevancePosition{X: 100, Y: 10})
+		assert.NoError(err)
+		withoutPosition, err := Spawn(world, &relevanceScore{})
+		assert.NoError(err)
+
+		relevance, err := NewRelevance[NoFilter](world, WithinRadius[relevancePosition](avatar, 5))
+		assert.NoError(err)
+		tracker := NewDeltaTracker(nil)
+		tracker.SetRelevance(relevance)
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{avatar, near, withoutPosition}, delta.Spawned)
+
+		position, err := Get1[*relevancePosition](world, far)
+		assert.NoError(err)
+		position.X = 12
+		position, err = Get1[*relevancePosition](world, near)
+		assert.NoError(err)
+		position.X = 20
+
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{far}, delta.Spawned)
+		assert.Equal([]EntityId{near}, delta.Despawned)
+
+		// nothing with a position is relevant without a center
+		assert.NoError(Despawn(world, avatar))
+		delta, err = tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{avatar, far}, delta.Despawned)
+	})
+
+	t.Run("returns an error when the filter contains a change filter", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := NewRelevance[Or[With[relevanceHidden], Changed[relevanceScore]]](world)
+		assert.ErrorIs(err, ErrQueryChangeFilterNotSupported)
+	})
+
+	t.Run("entities within the radius of the center are relevant when Position has a pointer receiver", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		center, err := Spawn(world, &relevancePointerPosition{X: 1})
+		assert.NoError(err)
+		near, err := Spawn(world, &relevancePointerPosition{X: 3})
+		assert.NoError(err)
+		_, err = Spawn(world, &relevancePointerPosition{X: 50})
+		assert.NoError(err)
+
+		relevance, err := NewRelevance[NoFilter](world, WithinRadius[*relevancePointerPosition](center, 5))
+		assert.NoError(err)
+		tracker := NewDeltaTracker(nil)
+		tracker.SetRelevance(relevance)
+
+		delta, err := tracker.Next(world)
+		assert.NoError(err)
+		assert.Equal([]EntityId{center, near}, delta.Spawned)
+	})
+}
@@ -2,8 +2,8 @@
 package ecs
 
 import (
+	"errors"
 	"fmt"
-	"slices"
 
 	"github.com/lucdrenth/murphecs/src/utils"
 )
@@ -12,10 +12,11 @@ import (
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the component is not present in the entity.
 //   - ErrWorldIsLocked error while querying
 func Remove1[A AnyComponent](world *World, entity EntityId) error {
-	if world.isQuerying {
+	if world.isQuerying() {
 		// Prevent archetype moves during querying to prevent unexpected behavior.
 		return ErrWorldIsLocked
 	}
@@ -29,10 +30,11 @@ func Remove1[A AnyComponent](world *World, entity EntityId) error {
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the component is not present in the entity.
 //   - ErrWorldIsLocked error while querying
 func Remove2[A, B AnyComponent](world *World, entity EntityId) (result error) {
-	if world.isQuerying {
+	if world.isQuerying() {
 		// Prevent archetype moves during querying to prevent unexpected behavior.
 		return ErrWorldIsLocked
 	}
@@ -47,10 +49,11 @@ func Remove2[A, B AnyComponent](world *World, entity EntityId) (result error) {
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the component is not present in the entity.
 //   - ErrWorldIsLocked error while querying
 func Remove3[A, B, C AnyComponent](world *World, entity EntityId) (result error) {
-	if world.isQuerying {
+	if world.isQuerying() {
 		// Prevent archetype moves during querying to prevent unexpected behavior.
 		return ErrWorldIsLocked
 	}
@@ -66,10 +69,11 @@ func Remove3[A, B, C AnyComponent](world *World, entity EntityId) (result error)
 //
 // Can return the following errors:
 //   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
 //   - ErrComponentNotFound error if the component is not present in the entity.
 //   - ErrWorldIsLocked error while querying
 func Remove4[A, B, C, D AnyComponent](world *World, entity EntityId) (result error) {
-	if world.isQuerying {
+	if world.isQuerying() {
 		// Prevent archetype moves during querying to prevent unexpected behavior.
 		return ErrWorldIsLocked
 	}
@@ -82,10 +86,35 @@ func Remove4[A, B, C, D AnyComponent](world *World, entity EntityId) (result err
 	})
 }
 
+// RemoveById removes the components with the given ids from entity. Use this when the component types are only
+// known at runtime, and [Remove1] and so on otherwise. Relation pairs can be removed as well, see [RelationIdFor].
+//
+// Can return the following errors:
+//   - ErrEntityNotFound error if the entity does not exist in world.
+//   - ErrEntityStale error if the entity has been despawned.
+//   - ErrComponentNotFound error if any of the components is not present in the entity, while still removing the
+//     components that are present.
+//   - ErrComponentDuplicate error if a component id is given multiple times.
+//   - ErrWorldIsLocked error while querying
+func RemoveById(world *World, entity EntityId, componentIds ...ComponentId) error {
+	if world.isQuerying() {
+		// Prevent archetype moves during querying to prevent unexpected behavior.
+		return ErrWorldIsLocked
+	}
+
+	for _, componentId := range componentIds {
+		if componentId.componentType == nil {
+			return fmt.Errorf("%w: invalid component id", ErrComponentNotFound)
+		}
+	}
+
+	return removeComponents(world, entity, componentIds)
+}
+
 func removeComponents(world *World, entityId EntityId, componentIds []ComponentId) (resultErr error) {
-	entityData, ok := world.entities[entityId]
-	if !ok {
-		return ErrEntityNotFound
+	entityData, err := world.entities.get(entityId)
+	if err != nil {
+		return err
 	}
 
 	duplicate, duplicateIndexA, duplicateIndexB := utils.GetFirstDuplicate(componentIds)
@@ -107,76 +136,77 @@ func removeComponents(world *World, entityId EntityId, componentIds []ComponentI
 	}
 
 	oldArchetype := entityData.archetype
+	hierarchyRemoval := prepareHierarchyRemoval(world, entityId, oldArchetype, componentIdsToRemove)
 
-	newComponentIds := make([]ComponentId, 0, len(oldArchetype.componentIds))
-	for _, componentId := range oldArchetype.componentIds {
-		if !slices.Contains(componentIdsToRemove, componentId) {
-			newComponentIds = append(newComponentIds, componentId)
-		}
-	}
-
-	newArchetype, err := world.archetypeStorage.getArchetype(world, newComponentIds)
+	newArchetype, err := world.archetypeStorage.getArchetypeAfterRemove(world, oldArchetype, componentIdsToRemove)
 	if err != nil {
 		return err
 	}
 
-	var newRow uint
-	var movedComponent *movedComponent
-
 	for componentId, oldStorage := range oldArchetype.components {
-		if newArchetype.HasComponent(componentId) {
-			rawComponent, err := oldStorage.getComponentPointer(entityData.row)
-			if err != nil {
-				resultErr = err
-				continue
-			}
-
-			storage := newArchetype.components[componentId]
-			newRow, err = storage.insertRaw(world, rawComponent)
-			if err != nil {
-				resultErr = err
-				continue
-			}
+		if !newArchetype.HasComponent(componentId) {
+			continue
 		}
 
-		removeResult, err := oldStorage.remove(entityData.row)
+		rawComponent, err := oldStorage.getComponentPointer(entityData.row)
 		if err != nil {
-			resultErr = err
-			continue
+			return err
 		}
 
-		movedComponent = removeResult
+		_, err = newArchetype.components[componentId].insertRaw(world, rawComponent, oldStorage.ticks[entityData.row])
+		if err != nil {
+			return err
+		}
 	}
 
-	handleComponentStorageIndexMove(world, movedComponent, oldArchetype)
-	err = oldArchetype.removeEntity(entityId)
+	err = removeEntityFromArchetype(world, oldArchetype, entityData.row)
 	if err != nil {
-		resultErr = fmt.Errorf("failed to remove entity from old archetype: %w", err)
+		return err
 	}
 
 	entityData.archetype = newArchetype
-	entityData.row = newRow
-	world.archetypeStorage.entityIdToArchetype[entityId] = newArchetype
-	newArchetype.entities = append(newArchetype.entities, entityId)
+	entityData.row = newArchetype.addEntity(entityId)
+
+	world.removedComponents.record(world, entityId, componentIdsToRemove)
+
+	if err := hierarchyRemoval.apply(world, entityId); err != nil {
+		resultErr = errors.Join(resultErr, err)
+	}
+
+	// Observers may spawn entities, which invalidates entityData.
+	entityObservers := entityData.observers
 
 	world.observers.triggerDespawnObservers(world, componentIds, entityId)
-	if entityData.observers != nil {
-		entityData.observers.triggerDespawnObservers(world, componentIds, entityId)
+	if entityObservers != nil {
+		entityObservers.triggerDespawnObservers(world, componentIds, entityId)
 	}
 
 	return resultErr
 }
 
-func handleComponentStorageIndexMove(world *World, movedComponent *movedComponent, archetype *Archetype) {
-	if movedComponent == nil {
-		return
+// removeEntityFromArchetype removes the components and the entity at row from archetype. The entity that
+// gets moved to row to fill up the gap gets its row updated.
+func removeEntityFromArchetype(world *World, archetype *Archetype, row uint) error {
+	for _, storage := range archetype.components {
+		_, err := storage.remove(row)
+		if err != nil {
+			return err
+		}
 	}
 
-	for _, entityId := range archetype.entities {
-		entityData := world.entities[entityId]
+	movedEntity, isMoved, err := archetype.removeEntity(row)
+	if err != nil {
+		return fmt.Errorf("failed to remove entity from archetype: %w", err)
+	}
 
-		if entityData.row == movedComponent.fromIndex {
-			entityData.row = movedComponent.toIndex
+	if isMoved {
+		movedEntityData, err := world.entities.get(movedEntity)
+		if err != nil {
+			return fmt.Errorf("failed to update moved entity: %w", err)
 		}
+
+		movedEntityData.row = row
 	}
+
+	return nil
 }
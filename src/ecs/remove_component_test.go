@@ -272,3 +272,54 @@ func TestRemove4(t *testing.T) {
 		assert.ErrorIs(err, ErrComponentNotFound)
 	})
 }
+
+func TestRemoveById(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+	type likes struct{ Relation }
+
+	t.Run("removes the components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		target, err := Spawn(world)
+		assert.NoError(err)
+		entity, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, entity, target, &likes{}))
+
+		err = RemoveById(world, entity, ComponentIdFor[componentA](world), RelationIdFor[likes](world, target))
+		assert.NoError(err)
+
+		componentIds, err := ComponentIdsOfEntity(world, entity)
+		assert.NoError(err)
+		assert.Equal([]ComponentId{ComponentIdFor[componentB](world)}, componentIds)
+	})
+
+	t.Run("returns an error if the entity does not have the component", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		err = RemoveById(world, entity, ComponentIdFor[componentA](world), ComponentIdFor[componentB](world))
+		assert.ErrorIs(err, ErrComponentNotFound)
+		hasA, err := HasComponent[componentA](world, entity)
+		assert.NoError(err)
+		assert.False(hasA)
+
+		err = RemoveById(world, entity, ComponentId{})
+		assert.ErrorIs(err, ErrComponentNotFound)
+	})
+
+	t.Run("returns an error while querying", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		world.startQuerying()
+		err = RemoveById(world, entity, ComponentIdFor[componentA](world))
+		world.stopQuerying()
+		assert.ErrorIs(err, ErrWorldIsLocked)
+	})
+}
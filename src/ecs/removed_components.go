@@ -0,0 +1,135 @@
+package ecs
+
+import (
+	"reflect"
+	"slices"
+)
+
+// removedComponent is an entry of [removedComponentsLog].
+type removedComponent struct {
+	entity            EntityId
+	changeTick        uint32            // the change tick of the world at the time of removal, see [World.ChangeTick]
+	scheduleSystemsId ScheduleSystemsId // the [ScheduleSystems] during which the component got removed, 0 if none
+	tick              uint              // the tick of the app during which the component got removed
+}
+
+// removedComponentsLog keeps track of the entities that lost a component.
+type removedComponentsLog struct {
+	entries []removedComponent
+}
+
+// removedComponentsStorage stores a log for each component that is used by a [RemovedComponents] system param.
+// Removals of other components are not recorded.
+type removedComponentsStorage struct {
+	logs map[ComponentId]*removedComponentsLog
+}
+
+func newRemovedComponentsStorage() removedComponentsStorage {
+	return removedComponentsStorage{
+		logs: map[ComponentId]*removedComponentsLog{},
+	}
+}
+
+// getLog gets the log of componentId or creates and stores a new one.
+func (s *removedComponentsStorage) getLog(componentId ComponentId) *removedComponentsLog {
+	log, exists := s.logs[componentId]
+	if !exists {
+		log = &removedComponentsLog{}
+		s.logs[componentId] = log
+	}
+
+	return log
+}
+
+// record adds entity to the logs of componentIds. Components without a log are ignored.
+func (s *removedComponentsStorage) record(world *World, entity EntityId, componentIds []ComponentId) {
+	if len(s.logs) == 0 {
+		return
+	}
+
+	for _, componentId := range componentIds {
+		log, exists := s.logs[componentId]
+		if !exists {
+			continue
+		}
+
+		log.entries = append(log.entries, removedComponent{
+			entity:            entity,
+			changeTick:        world.ChangeTick(),
+			scheduleSystemsId: world.currentScheduleSystemsId,
+			tick:              world.currentTick,
+		})
+	}
+}
+
+// clear removes all entries that satisfy the following, just like [EventReader.ClearEvents] does for events:
+//   - removed during [ScheduleSystems] with given [ScheduleSystemsId], or not during any ScheduleSystems
+//   - AND removed at least 1 tick back
+func (s *removedComponentsStorage) clear(scheduleSystemsId ScheduleSystemsId, currentTick uint) {
+	for _, log := range s.logs {
+		log.entries = slices.DeleteFunc(log.entries, func(entry removedComponent) bool {
+			isOwnSchedule := entry.scheduleSystemsId == scheduleSystemsId || entry.scheduleSystemsId == 0
+			return isOwnSchedule && currentTick > entry.tick
+		})
+	}
+}
+
+// RemovedComponents can be used as a system param to get the entities that lost component C since the
+// system last ran, either because C got removed with [Remove1], [Remove2], [Remove3] or [Remove4], or
+// because the entity got despawned with [Despawn].
+//
+// Removals that happen while the schedule of the system runs are listed during the next run of the system.
+// Removals stay available for one full iteration of the schedule during which they happened, just like
+// events in an [EventReader].
+type RemovedComponents[C AnyComponent] struct {
+	log         *removedComponentsLog
+	changeTicks queryChangeTicks
+}
+
+// Read ranges over the entities that lost component C since the system last ran. The entities may no
+// longer exist in the world.
+func (r *RemovedComponents[C]) Read(yield func(EntityId) bool) {
+	for _, entry := range r.log.entries {
+		if !r.changeTicks.isNewer(entry.changeTick) {
+			continue
+		}
+
+		if !yield(entry.entity) {
+			return
+		}
+	}
+}
+
+// Len returns the number of entities that lost component C since the system last ran.
+func (r *RemovedComponents[C]) Len() int {
+	result := 0
+	for range r.Read {
+		result++
+	}
+
+	return result
+}
+
+// IsEmpty returns whether no entities lost component C since the system last ran.
+func (r *RemovedComponents[C]) IsEmpty() bool {
+	for range r.Read {
+		return false
+	}
+
+	return true
+}
+
+func (r *RemovedComponents[C]) prepare(world *World) {
+	r.log = world.removedComponents.getLog(ComponentIdFor[C](world))
+}
+
+func (r *RemovedComponents[C]) setChangeTicks(lastRun uint32, thisRun uint32) {
+	r.changeTicks = queryChangeTicks{lastRun: lastRun, thisRun: thisRun, isSetBySystem: true}
+}
+
+type AnyRemovedComponents interface {
+	prepare(world *World)
+	setChangeTicks(lastRun uint32, thisRun uint32)
+}
+
+var removedComponentsType = reflect.TypeFor[AnyRemovedComponents]()
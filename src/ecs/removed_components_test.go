@@ -0,0 +1,161 @@
+package ecs
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovedComponents(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+
+	t.Run("lists entities that lost the component through Remove and Despawn", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		entityA, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+		entityB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		_, err = Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		removed := [][]EntityId{}
+		err = scheduleSystems.add(func(removedComponents *RemovedComponents[componentA]) {
+			removed = append(removed, slices.Collect(removedComponents.Read))
+		}, "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		assert.NoError(Remove1[componentA](world, entityA))
+		assert.NoError(Despawn(world, entityB))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 1))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 2))
+
+		assert.Equal([][]EntityId{nil, {entityA, entityB}, nil}, removed)
+	})
+
+	t.Run("does not list removals of other components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		entity, err := Spawn(world, &componentA{}, &componentB{})
+		assert.NoError(err)
+
+		numberOfRemoved := []int{}
+		err = scheduleSystems.add(func(removedComponents *RemovedComponents[componentA]) {
+			numberOfRemoved = append(numberOfRemoved, removedComponents.Len())
+		}, "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		assert.NoError(Remove1[componentB](world, entity))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		assert.Equal([]int{0}, numberOfRemoved)
+	})
+
+	t.Run("lists removals by other systems during the next run", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		entityA, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		entityB, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		removed := [][]EntityId{}
+		err = scheduleSystems.add(Systems(
+			func() error {
+				if len(removed) == 0 {
+					return Remove1[componentA](world, entityA)
+				}
+				return nil
+			},
+			func(removedComponents *RemovedComponents[componentA]) {
+				removed = append(removed, slices.Collect(removedComponents.Read))
+			},
+			func() error {
+				if len(removed) == 1 {
+					return Despawn(world, entityB)
+				}
+				return nil
+			},
+		), "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 1))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 2))
+
+		// the removals of the first run are listed during the second run, regardless of the system order
+		assert.Equal([][]EntityId{nil, {entityA, entityB}, nil}, removed)
+	})
+
+	t.Run("lists removals that got applied through Commands", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		numberOfRemoved := []int{}
+		err = scheduleSystems.add(Systems(
+			func(removedComponents *RemovedComponents[componentA]) {
+				numberOfRemoved = append(numberOfRemoved, removedComponents.Len())
+			},
+			func(commands *Commands) {
+				if len(numberOfRemoved) == 1 {
+					commands.Despawn(entity)
+				}
+			},
+		), "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 1))
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 2))
+
+		assert.Equal([]int{0, 1, 0}, numberOfRemoved)
+	})
+
+	t.Run("removals are cleared after one full iteration of the schedule", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		entity, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+
+		err = scheduleSystems.add(func(removedComponents *RemovedComponents[componentA]) {}, "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.NoError(err)
+
+		assert.NoError(Remove1[componentA](world, entity))
+		assert.Len(world.removedComponents.logs[ComponentIdFor[componentA](world)].entries, 1)
+
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 0))
+		assert.Len(world.removedComponents.logs[ComponentIdFor[componentA](world)].entries, 1)
+
+		assert.Empty(scheduleSystems.Exec(world, nil, &eventStorage, 1))
+		assert.Empty(world.removedComponents.logs[ComponentIdFor[componentA](world)].entries)
+	})
+
+	t.Run("returns an error when not used as a pointer", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		err := scheduleSystems.add(func(_ RemovedComponents[componentA]) {}, "", world, nil, &NoOpLogger{}, &eventStorage)
+		assert.ErrorIs(err, ErrSystemParamRemovedComponentsNotAPointer)
+	})
+}
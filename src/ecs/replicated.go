@@ -0,0 +1,31 @@
+package ecs
+
+import "reflect"
+
+// AnyReplicated is a component or relation type that is replicated from a server world to client worlds. See
+// [Replicated].
+type AnyReplicated interface {
+	isReplicated()
+}
+
+// Replicated can be embedded in to a component or relation type to mark it as replicated, such as:
+//
+//	type Position struct {
+//		ecs.Component
+//		ecs.Replicated
+//		X, Y float64
+//	}
+//
+// Replication is opt-in per type: only components of replicated types are sent to clients, and only entities that have
+// at least one replicated component exist on clients. See [IsReplicated] and [DeltaTracker].
+type Replicated struct{}
+
+func (Replicated) isReplicated() {}
+
+var anyReplicatedType = reflect.TypeFor[AnyReplicated]()
+
+// IsReplicated returns whether the type of componentId embeds [Replicated]. This can be used as filter for
+// [NewDeltaTracker].
+func IsReplicated(componentId ComponentId) bool {
+	return componentId.componentType.Implements(anyReplicatedType)
+}
@@ -12,6 +12,7 @@ type Resource any
 type resourceStorage struct {
 	resources            map[resourceId]Resource
 	blacklistedResources []resourceId // resources that may not be added to this resourceStorage
+	registeredTypes      []resourceId // resource types that can be looked up by their name, see RegisterResource
 }
 
 func newResourceStorage() resourceStorage {
@@ -72,6 +73,34 @@ func RegisterBlacklistedResource[T Resource](storage *resourceStorage) error {
 	return nil
 }
 
+// RegisterResource registers resource type R so that it can be looked up by its name with
+// [GetResourceTypeByString] before a resource of that type got added, such as when restoring a snapshot.
+func RegisterResource[R Resource](world *World) {
+	resourceId := reflectTypeToResourceId(reflect.TypeFor[R]())
+	if !slices.Contains(world.resources.registeredTypes, resourceId) {
+		world.resources.registeredTypes = append(world.resources.registeredTypes, resourceId)
+	}
+}
+
+// GetResourceTypeByString returns the resource type of which the name (see [reflect.Type.String]) is typeString.
+// Resources that are present and resource types that got registered with [RegisterResource] are looked up.
+// Returns nil if there is no such resource type. The returned type is never a pointer.
+func GetResourceTypeByString(world *World, typeString string) reflect.Type {
+	for resourceId := range world.resources.resources {
+		if resourceId.String() == typeString {
+			return resourceId
+		}
+	}
+
+	for _, resourceId := range world.resources.registeredTypes {
+		if resourceId.String() == typeString {
+			return resourceId
+		}
+	}
+
+	return nil
+}
+
 func GetResource[T Resource](world *World) (result T, err error) {
 	resourceType := reflect.TypeFor[T]()
 	resourceId := reflectTypeToResourceId(resourceType)
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/lucdrenth/murphecs/src/utils"
@@ -155,13 +156,35 @@ type ScheduleSystems struct {
 
 	isPaused               atomic.Bool
 	isFirstExecSincePaused bool
+
+	// systemDependencies[i] are the indices of the systems that need to be done before the i'th system can run
+	// when executing in parallel. It is nil if it has not been computed since the last system got added.
+	systemDependencies [][]int
 }
 
 func (s *ScheduleSystems) Id() ScheduleSystemsId {
 	return s.id
 }
 
+// Exec runs the systems one after another, in the order that they got added.
 func (s *ScheduleSystems) Exec(world *World, outerWorlds *map[WorldId]*World, eventStorage *EventStorage, currentTick uint) []error {
+	return s.exec(world, outerWorlds, eventStorage, currentTick, s.execSystems)
+}
+
+// ExecParallel runs systems that do not conflict with each other in parallel. Two systems conflict if one
+// of them writes data that the other one reads or writes, where data is written if it is used as a pointer
+// (for example a pointer component in a query, or a pointer resource). Systems that have a [*World] param
+// conflict with all other systems. Conflicting systems and the systems of a chained group (see [Systems])
+// run in the order that they got added.
+//
+// Returned errors are ordered by the order in which the systems got added.
+func (s *ScheduleSystems) ExecParallel(world *World, outerWorlds *map[WorldId]*World, eventStorage *EventStorage, currentTick uint) []error {
+	return s.exec(world, outerWorlds, eventStorage, currentTick, func() []error {
+		return s.execSystemsParallel(world.numberOfParallelWorkers())
+	})
+}
+
+func (s *ScheduleSystems) exec(world *World, outerWorlds *map[WorldId]*World, eventStorage *EventStorage, currentTick uint, execSystems func() []error) []error {
 	if s.isPaused.Load() {
 		if s.isFirstExecSincePaused {
 			// The first exec since the schedule is paused needs to call ProcessEvents
@@ -169,6 +192,7 @@ func (s *ScheduleSystems) Exec(world *World, outerWorlds *map[WorldId]*World, ev
 			// If we don't do this, the event in the readers will never be cleared and
 			// can be infinitely read.
 			eventStorage.ProcessEvents(s.id, currentTick)
+			world.removedComponents.clear(s.id, currentTick)
 			s.isFirstExecSincePaused = false
 		}
 
@@ -182,15 +206,15 @@ func (s *ScheduleSystems) Exec(world *World, outerWorlds *map[WorldId]*World, ev
 	}
 	defer eventStorage.ProcessEvents(s.id, currentTick)
 
-	world.Mutex.Lock()
-	defer world.Mutex.Unlock()
-
+	worlds := []*World{world}
 	if outerWorlds != nil {
 		for _, outerWorld := range *outerWorlds {
-			outerWorld.Mutex.Lock()
-			defer outerWorld.Mutex.Unlock()
+			worlds = append(worlds, outerWorld)
 		}
 	}
+	defer lockWorlds(worlds...)()
+
+	s.advanceChangeTicks(world)
 
 	err := s.handleSystemParamQueries(world, outerWorlds)
 	if err != nil {
@@ -209,9 +233,59 @@ func (s *ScheduleSystems) Exec(world *World, outerWorlds *map[WorldId]*World, ev
 	}
 
 	world.currentScheduleSystemsId = s.id
+	world.currentTick = currentTick
 	defer func() { world.currentScheduleSystemsId = 0 }()
 
-	return s.execSystems()
+	errors := execSystems()
+	s.applyCommands(world)
+	s.finishChangeTicks()
+	world.checkChangeTicks()
+	world.removedComponents.clear(s.id, currentTick)
+
+	return errors
+}
+
+// advanceChangeTicks gives each system a new change tick, and passes the ticks to the queries of the systems so
+// that the queries use the last run tick of their system for the [Added] and [Changed] filters. Afterwards the
+// change tick of the world is advanced once more, so that changes that are made while the systems run are
+// newer than the ticks of all systems.
+func (s *ScheduleSystems) advanceChangeTicks(world *World) {
+	world.scheduleChangeTick = world.ChangeTick()
+	for _, system := range s.getSystems() {
+		system.thisRunTick = world.advanceChangeTick()
+		for _, query := range system.queries {
+			query.setChangeTicks(system.lastRunTick, system.thisRunTick)
+		}
+		for _, removedComponents := range system.removedComponents {
+			removedComponents.setChangeTicks(system.lastRunTick, system.thisRunTick)
+		}
+	}
+
+	world.advanceChangeTick()
+}
+
+// finishChangeTicks stores the tick at which each system ran, so that its next run only sees changes that came after.
+func (s *ScheduleSystems) finishChangeTicks() {
+	for _, system := range s.getSystems() {
+		system.lastRunTick = system.thisRunTick
+	}
+}
+
+// applyCommands applies the commands that systems recorded using a [Commands] param, in the order that
+// the systems got added. Errors are logged because they do not belong to the system that recorded them.
+func (s *ScheduleSystems) applyCommands(world *World) {
+	for _, systemGroup := range s.systemGroups {
+		for i := range systemGroup.systems {
+			commands := systemGroup.systems[i].commands
+			if commands == nil {
+				continue
+			}
+
+			for _, err := range commands.Apply() {
+				world.logger.Error("%s: failed to apply command: %v", systemGroup.systems[i].sourcePath, err)
+			}
+		}
+	}
 }
 
 func (s *ScheduleSystems) handleSystemParamQueries(world *World, outerWorlds *map[WorldId]*World) error {
@@ -309,6 +383,86 @@ func (s *ScheduleSystems) execSystems() []error {
 	return errors
 }
 
+func (s *ScheduleSystems) execSystemsParallel(numberOfWorkers int) []error {
+	systems := s.getSystems()
+	dependencies := s.getSystemDependencies()
+
+	systemErrors := make([]error, len(systems))
+	done := make([]chan struct{}, len(systems))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	workers := make(chan struct{}, max(numberOfWorkers, 1))
+
+	wg := sync.WaitGroup{}
+	for i, system := range systems {
+		wg.Go(func() {
+			defer close(done[i])
+
+			for _, dependency := range dependencies[i] {
+				<-done[dependency]
+			}
+
+			workers <- struct{}{}
+			systemErrors[i] = system.exec()
+			<-workers
+		})
+	}
+	wg.Wait()
+
+	errors := []error{}
+	for _, err := range systemErrors {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	return errors
+}
+
+// getSystems returns the systems of all system groups, in the order that they got added.
+func (s *ScheduleSystems) getSystems() []*systemEntry {
+	systems := []*systemEntry{}
+	for i := range s.systemGroups {
+		for j := range s.systemGroups[i].systems {
+			systems = append(systems, &s.systemGroups[i].systems[j])
+		}
+	}
+	return systems
+}
+
+// getSystemDependencies returns, for each system in the order of getSystems, the indices of the systems that
+// have to be done before that system can run in parallel with others.
+func (s *ScheduleSystems) getSystemDependencies() [][]int {
+	if s.systemDependencies != nil {
+		return s.systemDependencies
+	}
+
+	dependencies := [][]int{}
+	accesses := []*systemAccess{}
+
+	for _, systemGroup := range s.systemGroups {
+		for i := range systemGroup.systems {
+			access := &systemGroup.systems[i].access
+			systemIndex := len(accesses)
+			systemDependencies := []int{}
+
+			for other, otherAccess := range accesses {
+				isPreviousInChain := systemGroup.chain && i > 0 && other == systemIndex-1
+				if isPreviousInChain || access.conflictsWith(otherAccess) {
+					systemDependencies = append(systemDependencies, other)
+				}
+			}
+
+			dependencies = append(dependencies, systemDependencies)
+			accesses = append(accesses, access)
+		}
+	}
+
+	s.systemDependencies = dependencies
+	return dependencies
+}
+
 func (s *ScheduleSystems) add(sys System, source string, world *World, outerWorlds *map[WorldId]*World, logger Logger, eventStorage *EventStorage) error {
 	systemValue := reflect.ValueOf(sys)
 	systemGroupBuilderType2 := reflect.TypeFor[*systemGroupBuilder]()
@@ -333,6 +487,7 @@ func (s *ScheduleSystems) add(sys System, source string, world *World, outerWorl
 		return err
 	}
 	s.systemGroups = append(s.systemGroups, systemGroup)
+	s.systemDependencies = nil
 
 	return nil
 }
@@ -341,6 +496,13 @@ type systemEntry struct {
 	system     reflect.Value
 	params     []reflect.Value
 	sourcePath string
+	access     systemAccess // the data that the system reads and writes, used to run systems in parallel
+	commands   *Commands    // nil if the system does not have a Commands param
+
+	queries           []Query // the query params that target the world of the system, which use the change ticks of the system
+	removedComponents []AnyRemovedComponents
+	lastRunTick       uint32 // the change tick at which the system last ran, 0 if it did not run yet
+	thisRunTick       uint32
 }
 
 func (s *systemEntry) exec() error {
@@ -382,6 +544,7 @@ type systemGroup struct {
 	systemParamQueriesToOuterWorlds []queryToOuterWorld
 	outerResources                  []outerResourceParam
 	eventWriters                    []AnyEventWriter
+	chain                           bool
 }
 
 type systemGroupBuilder struct {
@@ -418,13 +581,17 @@ func (s *systemGroupBuilder) validate() error {
 }
 
 func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map[WorldId]*World, logger Logger, eventStorage *EventStorage) (systemGroup, error) {
-	systemGroup := systemGroup{}
+	systemGroup := systemGroup{chain: s.chain}
 
 	for _, sys := range s.systems {
 		systemValue := reflect.ValueOf(sys)
 
 		numberOfParams := systemValue.Type().NumIn()
 		params := make([]reflect.Value, numberOfParams)
+		access := newSystemAccess()
+		var commands *Commands
+		queries := []Query{}
+		removedComponents := []AnyRemovedComponents{}
 
 		for i := range numberOfParams {
 			parameterType := systemValue.Type().In(i)
@@ -442,11 +609,22 @@ func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map
 					})
 				} else {
 					systemGroup.systemParamQueries = append(systemGroup.systemParamQueries, query)
+					queries = append(queries, query)
 				}
 
+				access.addQuery(query)
 				params[i] = reflect.ValueOf(query)
 			} else if parameterType == reflect.TypeFor[*World]() {
+				access.isExclusive = true
 				params[i] = reflect.ValueOf(world)
+			} else if parameterType == reflect.TypeFor[*Commands]() {
+				// Commands only reserve entity ids while the system runs, so they do not conflict with other systems.
+				if commands == nil {
+					commands = NewCommands(world)
+				}
+				params[i] = reflect.ValueOf(commands)
+			} else if parameterType == reflect.TypeFor[Commands]() {
+				return systemGroup, fmt.Errorf("%s: parameter %s: %w", systemToDebugString(sys), systemParameterDebugString(sys, i), ErrSystemParamCommandsNotAPointer)
 			} else if parameterType == reflect.TypeFor[World]() {
 				// World may not be used by-value because:
 				//	1. it is a potentially big object and copying it could give bad performance
@@ -457,6 +635,8 @@ func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map
 				if !ok {
 					panic("failed to type assert AnyEventReader")
 				}
+				// event readers are shared between systems and reading events modifies the reader
+				access.write(newAccessKey(accessKindEventReader, nil, eventReader.ReaderEventId()))
 				params[i] = *eventStorage.GetReader(eventReader)
 			} else if parameterType.Implements(eventWriterType) {
 				eventWriter, ok := reflect.TypeAssert[AnyEventWriter](reflect.New(parameterType.Elem()))
@@ -470,7 +650,16 @@ func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map
 					panic("failed to type assert AnyEventWriter")
 				}
 				systemGroup.eventWriters = append(systemGroup.eventWriters, eventWriterParam)
+				access.write(newAccessKey(accessKindEventWriter, nil, eventWriter.WriterEventId()))
 				params[i] = reflectedEventWriter
+			} else if parameterType.Implements(removedComponentsType) {
+				removed, ok := reflect.TypeAssert[AnyRemovedComponents](reflect.New(parameterType.Elem()))
+				if !ok {
+					panic("failed to type assert AnyRemovedComponents")
+				}
+				removed.prepare(world)
+				removedComponents = append(removedComponents, removed)
+				params[i] = reflect.ValueOf(removed)
 			} else if parameterType.Implements(outerResourceType) {
 				return systemGroup, fmt.Errorf("%s: parameter %s: %w", systemToDebugString(sys), systemParameterDebugString(sys, i), ErrSystemParamOuterResourceIsAPointer)
 			} else if parameterType.Kind() != reflect.Pointer && reflect.PointerTo(parameterType).Implements(outerResourceType) {
@@ -485,6 +674,7 @@ func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map
 					resourceType:      resType,
 					outerResourceType: parameterType,
 				})
+				access.addResource(worldId, resType)
 
 				params[i] = reflect.Zero(parameterType)
 			} else {
@@ -497,6 +687,7 @@ func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map
 					return systemGroup, fmt.Errorf("%s: parameter %s: %w", systemToDebugString(sys), systemParameterDebugString(sys, i), err)
 				}
 
+				access.addResource(nil, parameterType)
 				if parameterType.Kind() == reflect.Pointer {
 					params[i] = resource
 				} else {
@@ -509,6 +700,11 @@ func (s *systemGroupBuilder) build(source string, world *World, outerWorlds *map
 			system:     systemValue,
 			params:     params,
 			sourcePath: source,
+			access:     access,
+			commands:   commands,
+			queries:    queries,
+
+			removedComponents: removedComponents,
 		}
 		systemGroup.systems = append(systemGroup.systems, entry)
 	}
@@ -529,6 +725,10 @@ func handleInvalidSystemParam(parameterType reflect.Type) error {
 		return fmt.Errorf("EventWriter: %w", ErrSystemParamEventWriterNotAPointer)
 	}
 
+	if parameterType.Kind() != reflect.Pointer && reflect.PointerTo(parameterType).Implements(removedComponentsType) {
+		return fmt.Errorf("RemovedComponents: %w", ErrSystemParamRemovedComponentsNotAPointer)
+	}
+
 	return ErrSystemParamNotValid
 }
 
@@ -0,0 +1,359 @@
+package ecs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// jsonSnapshot is the JSON representation of a world, see [SnapshotJSON].
+type jsonSnapshot struct {
+	Entities  []jsonEntitySnapshot       `json:"entities"`
+	Resources map[string]json.RawMessage `json:"resources"`
+}
+
+type jsonEntitySnapshot struct {
+	Id EntityId `json:"id"`
+
+	// Components maps the type name of each component to its value.
+	Components map[string]json.RawMessage `json:"components"`
+
+	Relations []jsonRelationSnapshot `json:"relations,omitempty"`
+}
+
+type jsonRelationSnapshot struct {
+	Type   string          `json:"type"`
+	Target EntityId        `json:"target"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// SnapshotJSON encodes all entities of world, together with their components and relations, and all resources of
+// world as JSON. Use [RestoreJSON] to load the snapshot.
+//
+// Components and resources are encoded with [json.Marshal], so only their exported fields are saved unless they
+// implement [json.Marshaler]. Components and resources are stored by their type name (see [reflect.Type.String]).
+// [Children] are not saved because they are rebuilt from the [Parent] components when restoring.
+//
+// Can return the following errors:
+//   - Returns an error when any of the components or resources can not be encoded as JSON
+func SnapshotJSON(world *World) ([]byte, error) {
+	snapshot := jsonSnapshot{
+		Entities:  []jsonEntitySnapshot{},
+		Resources: map[string]json.RawMessage{},
+	}
+
+	childrenId := ComponentIdFor[Children](world)
+
+	for _, archetype := range world.archetypeStorage.archetypes {
+		for row, entity := range archetype.entities {
+			entitySnapshot := jsonEntitySnapshot{
+				Id:         entity,
+				Components: map[string]json.RawMessage{},
+			}
+
+			for _, componentId := range archetype.componentIds {
+				if componentId == childrenId {
+					continue
+				}
+
+				rawComponent, err := archetype.components[componentId].getComponentPointer(uint(row))
+				if err != nil {
+					return nil, err
+				}
+
+				data, err := json.Marshal(reflect.NewAt(componentId.componentType, rawComponent).Interface())
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode component %s of entity %s: %w", componentId.DebugString(), entity, err)
+				}
+
+				if target, isRelation := componentId.Target(); isRelation {
+					entitySnapshot.Relations = append(entitySnapshot.Relations, jsonRelationSnapshot{
+						Type:   componentId.componentType.String(),
+						Target: target,
+						Value:  data,
+					})
+				} else {
+					entitySnapshot.Components[componentId.componentType.String()] = data
+				}
+			}
+
+			snapshot.Entities = append(snapshot.Entities, entitySnapshot)
+		}
+	}
+
+	slices.SortFunc(snapshot.Entities, func(a, b jsonEntitySnapshot) int {
+		return int(a.Id.index) - int(b.Id.index)
+	})
+
+	for resourceId, resource := range world.resources.resources {
+		data, err := json.Marshal(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode resource %s: %w", resourceId.String(), err)
+		}
+
+		snapshot.Resources[resourceId.String()] = data
+	}
+
+	return json.Marshal(snapshot)
+}
+
+// RestoreJSON spawns the entities of a snapshot that got created by [SnapshotJSON] in to world and adds its
+// resources. Resources that are already present in world are overwritten. Entities that already exist in world are
+// kept.
+//
+// The restored entities get new entity ids. All [EntityId] values in the components and resources of the snapshot,
+// including the ones in nested structs, pointers, slices, arrays and maps, are rewritten to the new entity ids. Entity
+// ids that do not point to an entity of the snapshot are rewritten to the zero value of EntityId. The returned map maps
+// the entity ids of the snapshot to the new entity ids.
+//
+// The component types of the snapshot, other than [Parent], must be known by world, see [RegisterComponent] and
+// [GetComponentTypeByString].
+// The resource types of the snapshot must be known by world as well, see [RegisterResource] and
+// [GetResourceTypeByString].
+//
+// If an entity can not be restored, all entities of the snapshot are despawned again.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotRegistered error when a component or resource type is not known by world
+//   - Returns an ErrWorldIsLocked error while querying
+//   - Returns an error when data is not a valid snapshot
+//   - Any error that [Insert] or [resourceStorage.Add] returns.
+func RestoreJSON(world *World, data []byte) (map[EntityId]EntityId, error) {
+	if world.isQuerying() {
+		// If we allow this, the restored entities may or may not be included in the query results, which is unpredictable.
+		return nil, ErrWorldIsLocked
+	}
+
+	var snapshot jsonSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	// decode everything before spawning anything, so that an invalid snapshot leaves world untouched
+	decoder := newJsonSnapshotDecoder(world)
+	components := make([][]reflect.Value, len(snapshot.Entities))
+	relations := make([][]reflect.Value, len(snapshot.Entities))
+	for i, entitySnapshot := range snapshot.Entities {
+		for typeName, value := range entitySnapshot.Components {
+			component, err := decoder.decodeComponent(typeName, value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode component of entity %s: %w", entitySnapshot.Id, err)
+			}
+			components[i] = append(components[i], component)
+		}
+
+		for _, relationSnapshot := range entitySnapshot.Relations {
+			relation, err := decoder.decodeComponent(relationSnapshot.Type, relationSnapshot.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode relation of entity %s: %w", entitySnapshot.Id, err)
+			}
+			relations[i] = append(relations[i], relation)
+		}
+	}
+
+	resources := make([]reflect.Value, 0, len(snapshot.Resources))
+	for typeName, value := range snapshot.Resources {
+		resourceType := GetResourceTypeByString(world, typeName)
+		if resourceType == nil {
+			return nil, fmt.Errorf("%w: resource %s", ErrSnapshotTypeNotRegistered, typeName)
+		}
+
+		resource := reflect.New(resourceType)
+		if err := json.Unmarshal(value, resource.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to decode resource %s: %w", typeName, err)
+		}
+		resources = append(resources, resource)
+	}
+
+	entityMap := make(map[EntityId]EntityId, len(snapshot.Entities))
+	for _, entitySnapshot := range snapshot.Entities {
+		entity, err := Spawn(world)
+		if err != nil {
+			return nil, errors.Join(err, despawnRestored(world, entityMap))
+		}
+		entityMap[entitySnapshot.Id] = entity
+	}
+
+	for i, entitySnapshot := range snapshot.Entities {
+		entity := entityMap[entitySnapshot.Id]
+
+		entityComponents := make([]AnyComponent, len(components[i]))
+		for j, component := range components[i] {
+			remapEntityIds(component, entityMap)
+			entityComponents[j] = component.Interface().(AnyComponent)
+		}
+
+		if len(entityComponents) == 0 {
+			continue
+		}
+
+		if err := Insert(world, entity, entityComponents...); err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("failed to restore entity %s: %w", entitySnapshot.Id, err),
+				despawnRestored(world, entityMap),
+			)
+		}
+	}
+
+	// relations are restored after the components, so that the components of the targets are restored already
+	for i, entitySnapshot := range snapshot.Entities {
+		for j, relation := range relations[i] {
+			target, exists := entityMap[entitySnapshot.Relations[j].Target]
+			if !exists {
+				return nil, errors.Join(
+					fmt.Errorf("%w: %s", ErrRelationTargetNotFound, entitySnapshot.Relations[j].Target),
+					despawnRestored(world, entityMap),
+				)
+			}
+
+			remapEntityIds(relation, entityMap)
+			relationId := ComponentId{
+				id:            world.componentRegistry.getId(relation.Type().Elem()),
+				componentType: relation.Type().Elem(),
+				target:        target,
+			}
+
+			err := insert(world, entityMap[entitySnapshot.Id], []AnyComponent{relation.Interface().(AnyComponent)}, []ComponentId{relationId})
+			if err != nil {
+				return nil, errors.Join(
+					fmt.Errorf("failed to restore relation %s of entity %s: %w", relationId.DebugString(), entitySnapshot.Id, err),
+					despawnRestored(world, entityMap),
+				)
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		remapEntityIds(resource, entityMap)
+
+		existing, err := world.resources.GetReflectResource(resource.Type())
+		if err == nil {
+			existing.Elem().Set(resource.Elem())
+			continue
+		}
+
+		if err := world.resources.Add(resource.Interface()); err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("failed to restore resource %s: %w", resource.Type().Elem().String(), err),
+				despawnRestored(world, entityMap),
+			)
+		}
+	}
+
+	return entityMap, nil
+}
+
+// jsonSnapshotDecoder decodes the components of a snapshot and caches the types that it looked up by name.
+type jsonSnapshotDecoder struct {
+	world *World
+	types map[string]reflect.Type
+}
+
+func newJsonSnapshotDecoder(world *World) jsonSnapshotDecoder {
+	parentType := reflect.TypeFor[Parent]()
+
+	return jsonSnapshotDecoder{
+		world: world,
+		// built-in components do not have to be registered
+		types: map[string]reflect.Type{parentType.String(): parentType},
+	}
+}
+
+// decodeComponent returns a pointer to a new component of the type with the given name, decoded from value.
+func (decoder *jsonSnapshotDecoder) decodeComponent(typeName string, value json.RawMessage) (reflect.Value, error) {
+	componentType, exists := decoder.types[typeName]
+	if !exists {
+		componentType = GetComponentTypeByString(decoder.world, typeName)
+		if componentType == nil {
+			return reflect.Value{}, fmt.Errorf("%w: component %s", ErrSnapshotTypeNotRegistered, typeName)
+		}
+		decoder.types[typeName] = componentType
+	}
+
+	component := reflect.New(componentType)
+	if err := json.Unmarshal(value, component.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("failed to decode %s: %w", typeName, err)
+	}
+
+	return component, nil
+}
+
+// despawnRestored despawns the entities that got spawned while restoring a snapshot.
+func despawnRestored(world *World, entityMap map[EntityId]EntityId) error {
+	var result error
+	for _, entity := range entityMap {
+		if err := Despawn(world, entity); err != nil && !errors.Is(err, ErrEntityNotFound) {
+			result = errors.Join(result, err)
+		}
+	}
+
+	return result
+}
+
+var entityIdType = reflect.TypeFor[EntityId]()
+
+// remapEntityIds rewrites all settable EntityId values in value to the entity id that they map to in entityMap.
+// Entity ids that are not in entityMap are rewritten to the zero value of EntityId.
+func remapEntityIds(value reflect.Value, entityMap map[EntityId]EntityId) {
+	if value.Type() == entityIdType {
+		if value.CanSet() {
+			value.Set(reflect.ValueOf(entityMap[value.Interface().(EntityId)]))
+		}
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if !value.IsNil() {
+			remapEntityIds(value.Elem(), entityMap)
+		}
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+
+		if !value.CanSet() {
+			// the element of an interface is not settable, but values behind a pointer in it are
+			remapEntityIds(value.Elem(), entityMap)
+			return
+		}
+
+		// the element of an interface is not settable, so it is remapped as a copy that replaces it
+		element := reflect.New(value.Elem().Type()).Elem()
+		element.Set(value.Elem())
+		remapEntityIds(element, entityMap)
+		value.Set(element)
+	case reflect.Struct:
+		for i := range value.NumField() {
+			if value.Type().Field(i).IsExported() {
+				remapEntityIds(value.Field(i), entityMap)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			remapEntityIds(value.Index(i), entityMap)
+		}
+	case reflect.Map:
+		if value.IsNil() || !value.CanSet() {
+			return
+		}
+
+		// map keys and values are not addressable, so the map is rebuilt with copies of them
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			key := reflect.New(value.Type().Key()).Elem()
+			key.Set(iterator.Key())
+			remapEntityIds(key, entityMap)
+
+			element := reflect.New(value.Type().Elem()).Elem()
+			element.Set(iterator.Value())
+			remapEntityIds(element, entityMap)
+
+			result.SetMapIndex(key, element)
+		}
+		value.Set(result)
+	}
+}
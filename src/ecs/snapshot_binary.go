@@ -0,0 +1,886 @@
+package ecs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+const (
+	binarySnapshotMagic   = "MECS"
+	binarySnapshotVersion = 1
+)
+
+// binarySnapshotLayout describes the memory layout of the machine that created a binary snapshot. Pointer-free
+// components are written as they are laid out in memory, so a snapshot can only be restored on a machine with the
+// same layout.
+var binarySnapshotLayout = func() byte {
+	layout := byte(unsafe.Sizeof(uintptr(0)))
+	if binary.NativeEndian.Uint16([]byte{1, 0}) == 1 {
+		layout |= 0x80 // little endian
+	}
+	return layout
+}()
+
+const (
+	binaryEntityIdSize       = unsafe.Sizeof(EntityId{})
+	binaryComponentTicksSize = unsafe.Sizeof(componentTicks{})
+)
+
+// SnapshotBinary encodes all entities of world, together with their components, and all resources of world in a
+// compact binary format. Use [RestoreBinary] to load the snapshot.
+//
+// The component storages of each archetype are written as contiguous blocks. Components of types that do not contain
+// any pointers, strings, slices or maps are written in bulk as they are laid out in memory. Other components are
+// encoded field by field, including their unexported fields. Data behind pointers is copied, so components that share
+// data behind a pointer get their own copy of it when restoring.
+//
+// Binary snapshots can only be restored by the same version of this package, on a machine with the same memory layout.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotSupported error when a component or resource contains a non-nil interface,
+//     channel, function, unsafe.Pointer or a pointer cycle
+func SnapshotBinary(world *World) ([]byte, error) {
+	encoder := binaryEncoder{}
+	encoder.header()
+
+	// entities
+	world.entities.reservationMutex.Lock()
+	world.entities.flushReservations()
+	slots := world.entities.slots
+	freeIndices := slices.Clone(world.entities.freeIndices)
+	world.entities.reservationMutex.Unlock()
+
+	// the generations of the slots are followed by whether the slots are alive, both as contiguous blocks
+	generations := make([]uint32, len(slots))
+	isAlive := make([]byte, len(slots))
+	for index, slot := range slots {
+		generations[index] = slot.generation
+		if slot.isAlive {
+			isAlive[index] = 1
+		}
+
+		// reservations are not part of the snapshot, so reserved slots are restored as free slots
+		if slot.isReserved {
+			freeIndices = append(freeIndices, uint32(index))
+		}
+	}
+
+	encoder.uvarint(uint64(len(slots)))
+	encoder.raw(unsafe.Pointer(&generations[0]), uintptr(len(generations))*unsafe.Sizeof(generations[0]))
+	encoder.bytes(isAlive)
+
+	encoder.uvarint(uint64(len(freeIndices)))
+	for _, index := range freeIndices {
+		encoder.uvarint(uint64(index))
+	}
+
+	// component types
+	archetypes := []*Archetype{}
+	typeIndices := map[reflect.Type]uint64{}
+	types := []reflect.Type{}
+	for _, archetype := range world.archetypeStorage.archetypes {
+		if len(archetype.entities) == 0 {
+			continue
+		}
+		archetypes = append(archetypes, archetype)
+
+		for _, componentId := range archetype.componentIds {
+			if _, exists := typeIndices[componentId.componentType]; !exists {
+				typeIndices[componentId.componentType] = uint64(len(types))
+				types = append(types, componentId.componentType)
+			}
+		}
+	}
+
+	// The components and ticks take up most of the snapshot, so the buffer is grown once up front to fit them.
+	size := len(slots) * 5
+	for _, archetype := range archetypes {
+		rowSize := binaryEntityIdSize
+		for _, componentId := range archetype.componentIds {
+			rowSize += binaryComponentTicksSize + componentId.componentType.Size()
+		}
+		size += len(archetype.entities) * int(rowSize)
+	}
+	encoder.buffer = slices.Grow(encoder.buffer, size)
+
+	encoder.uvarint(uint64(len(types)))
+	for _, componentType := range types {
+		encoder.string(componentType.String())
+		encoder.uvarint(uint64(componentType.Size()))
+	}
+
+	// archetypes
+	encoder.uvarint(uint64(len(archetypes)))
+	for _, archetype := range archetypes {
+		numberOfEntities := len(archetype.entities)
+
+		encoder.uvarint(uint64(len(archetype.componentIds)))
+		for _, componentId := range archetype.componentIds {
+			encoder.uvarint(typeIndices[componentId.componentType])
+			encoder.entityId(componentId.target)
+		}
+
+		encoder.uvarint(uint64(numberOfEntities))
+		encoder.raw(unsafe.Pointer(&archetype.entities[0]), uintptr(numberOfEntities)*binaryEntityIdSize)
+
+		for _, componentId := range archetype.componentIds {
+			storage := archetype.components[componentId]
+			encoder.raw(unsafe.Pointer(&storage.ticks[0]), uintptr(numberOfEntities)*binaryComponentTicksSize)
+
+			if isPointerFree(componentId.componentType) {
+				encoder.raw(storage.pointerToStart, uintptr(numberOfEntities)*storage.componentSize)
+				continue
+			}
+
+			for row := range numberOfEntities {
+				if err := encoder.value(storage.data.Index(row)); err != nil {
+					return nil, fmt.Errorf("failed to encode component %s of entity %s: %w", componentId.DebugString(), archetype.entities[row], err)
+				}
+			}
+		}
+	}
+
+	// resources
+	encoder.uvarint(uint64(len(world.resources.resources)))
+	for resourceId, resource := range world.resources.resources {
+		encoder.string(resourceId.String())
+		if err := encoder.value(reflect.ValueOf(resource).Elem()); err != nil {
+			return nil, fmt.Errorf("failed to encode resource %s: %w", resourceId.String(), err)
+		}
+	}
+
+	return encoder.buffer, nil
+}
+
+// RestoreBinary restores world to the state of a snapshot that got created by [SnapshotBinary]. All entities of world
+// are replaced by the entities of the snapshot, which keep their entity ids. Resources that are already present in
+// world are overwritten by the resources of the snapshot, other resources of world are kept.
+//
+// The archetypes of the snapshot are rebuilt directly, so no observers are triggered and [RemovedComponents] are not
+// recorded. Entity ids that got reserved, such as by [Commands], can no longer be spawned after restoring. All
+// restored components are marked as changed at the current change tick of world, see [World.ChangeTick]. The
+// tick at which they got added is restored from the snapshot.
+//
+// The component types of the snapshot, other than [Parent] and [Children], must be known by world, see
+// [RegisterComponent] and [GetComponentTypeByString]. The resource types of the snapshot must be known by world as
+// well, see [RegisterResource] and [GetResourceTypeByString]. world is left untouched if the snapshot is not valid.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotRegistered error when a component or resource type is not known by world
+//   - Returns an ErrSnapshotNotValid error when data is not a valid binary snapshot, when it got created by another
+//     version of this package or on a machine with another memory layout, or when a component type has changed
+//   - Returns an ErrWorldIsLocked error while querying
+func RestoreBinary(world *World, data []byte) error {
+	if world.isQuerying() {
+		// If we allow this, queries that are being iterated would read from replaced component storages.
+		return ErrWorldIsLocked
+	}
+
+	snapshot, err := decodeBinarySnapshot(world, data)
+	if err != nil {
+		return err
+	}
+
+	// creating archetypes does not change the entities of world, so it is fine to do before changing world
+	archetypes := make([]*Archetype, len(snapshot.archetypes))
+	for i, restored := range snapshot.archetypes {
+		archetype, err := world.archetypeStorage.getArchetype(world, restored.sortedComponentIds)
+		if err != nil {
+			return err
+		}
+		archetypes[i] = archetype
+	}
+
+	// Everything got validated, so now world can be changed.
+	numberOfSlots := len(snapshot.generations)
+	world.entities.reservationMutex.Lock()
+	previousSlots := world.entities.slots
+	slots := previousSlots[:min(numberOfSlots, len(previousSlots))]
+	clear(previousSlots[len(slots):]) // so that the garbage collector can clean up what they point to
+	if cap(slots) < numberOfSlots {
+		slots = make([]entitySlot, numberOfSlots)
+	}
+	slots = slots[:numberOfSlots]
+
+	for index := range slots {
+		slot := entitySlot{
+			generation: snapshot.generations[index],
+			isAlive:    snapshot.isAlive[index] == 1,
+		}
+
+		// observers of entities that exist before and after restoring are kept
+		if index < len(previousSlots) {
+			previousSlot := previousSlots[index]
+			if previousSlot.isAlive && slot.isAlive && previousSlot.generation == slot.generation {
+				slot.data.observers = previousSlot.data.observers
+			}
+		}
+
+		slots[index] = slot
+	}
+	world.entities.slots = slots
+	world.entities.freeIndices = snapshot.freeIndices
+	world.entities.numberOfEntities = snapshot.numberOfEntities
+	world.entities.numberOfPendingSlots = 0
+	world.entities.reservationMutex.Unlock()
+
+	for _, archetype := range world.archetypeStorage.archetypes {
+		if len(archetype.entities) == 0 {
+			continue
+		}
+
+		archetype.entities = archetype.entities[:0]
+		for _, storage := range archetype.components {
+			storage.clear()
+		}
+	}
+
+	// Restored components count as changed, because the change ticks of world are not restored. Otherwise
+	// [Changed] filters and [DeltaTracker] would not see that they got changed back to the state of the snapshot.
+	changeTick := world.ChangeTick()
+	for i, restored := range snapshot.archetypes {
+		archetype := archetypes[i]
+		archetype.entities = restored.entities
+		for row, entity := range restored.entities {
+			slots[entity.index].data.archetype = archetype
+			slots[entity.index].data.row = uint(row)
+		}
+
+		for j, componentId := range restored.componentIds {
+			storage := restored.storages[j]
+			for row := range storage.ticks {
+				storage.ticks[row].changed = changeTick
+			}
+			archetype.components[componentId].replace(storage.data, uint(len(restored.entities)), storage.ticks)
+		}
+	}
+
+	for _, resource := range snapshot.resources {
+		if existing, err := world.resources.GetReflectResource(resource.Type()); err == nil {
+			existing.Elem().Set(resource.Elem())
+			continue
+		}
+
+		if err := world.resources.Add(resource.Interface()); err != nil {
+			return fmt.Errorf("failed to restore resource %s: %w", resource.Type().Elem().String(), err)
+		}
+	}
+
+	return nil
+}
+
+// binarySnapshot is a decoded snapshot that got created by [SnapshotBinary].
+type binarySnapshot struct {
+	generations      []uint32
+	isAlive          []byte
+	numberOfEntities int
+	freeIndices      []uint32
+	archetypes       []binarySnapshotArchetype
+	resources        []reflect.Value // pointers to the resources
+}
+
+type binarySnapshotArchetype struct {
+	componentIds       []ComponentId // in the order of storages
+	sortedComponentIds []ComponentId
+	entities           []EntityId
+	storages           []binarySnapshotStorage
+}
+
+type binarySnapshotStorage struct {
+	data  reflect.Value // an array with a component for each entity of the archetype
+	ticks []componentTicks
+}
+
+// decodeBinarySnapshot decodes and validates a snapshot that got created by [SnapshotBinary], without changing the
+// entities of world. The component and resource types are looked up in world.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotRegistered error when a component or resource type is not known by world
+//   - Returns an ErrSnapshotNotValid error when data is not a valid binary snapshot
+func decodeBinarySnapshot(world *World, data []byte) (*binarySnapshot, error) {
+	decoder := binaryDecoder{data: data}
+	if err := decoder.header(); err != nil {
+		return nil, err
+	}
+
+	// entities
+	numberOfSlots := decoder.length(5)
+	if decoder.err == nil && numberOfSlots == 0 {
+		return nil, fmt.Errorf("%w: missing entity slots", ErrSnapshotNotValid)
+	}
+	generations := make([]uint32, numberOfSlots)
+	if numberOfSlots > 0 {
+		decoder.raw(unsafe.Pointer(&generations[0]), uintptr(numberOfSlots)*unsafe.Sizeof(generations[0]))
+	}
+	isAlive := decoder.bytes(numberOfSlots)
+	numberOfEntities := 0
+	for _, alive := range isAlive {
+		if alive > 1 {
+			return nil, fmt.Errorf("%w: entity slot flags", ErrSnapshotNotValid)
+		}
+		numberOfEntities += int(alive)
+	}
+	if decoder.err == nil && isAlive[0] == 1 {
+		return nil, fmt.Errorf("%w: the first entity slot is alive", ErrSnapshotNotValid)
+	}
+
+	freeIndices := make([]uint32, decoder.length(1))
+	for i := range freeIndices {
+		freeIndices[i] = uint32(decoder.uvarint())
+		if decoder.err == nil && (freeIndices[i] == 0 || int(freeIndices[i]) >= numberOfSlots || isAlive[freeIndices[i]] == 1) {
+			return nil, fmt.Errorf("%w: free entity slot %d", ErrSnapshotNotValid, freeIndices[i])
+		}
+	}
+
+	// component types
+	types := make([]reflect.Type, decoder.length(2))
+	for i := range types {
+		typeName := decoder.string()
+		size := decoder.uvarint()
+		if decoder.err != nil {
+			break
+		}
+
+		componentType := snapshotComponentTypeByString(world, typeName)
+		if componentType == nil {
+			return nil, fmt.Errorf("%w: component %s", ErrSnapshotTypeNotRegistered, typeName)
+		}
+		if uint64(componentType.Size()) != size {
+			return nil, fmt.Errorf("%w: the size of component %s has changed", ErrSnapshotNotValid, typeName)
+		}
+
+		types[i] = componentType
+	}
+
+	// archetypes
+	archetypes := make([]binarySnapshotArchetype, decoder.length(1))
+	numberOfRestoredEntities := 0
+	isRestored := make([]bool, numberOfSlots) // to detect entities that are in multiple archetypes
+	for i := range archetypes {
+		componentIds := make([]ComponentId, decoder.length(2))
+		for j := range componentIds {
+			typeIndex := decoder.uvarint()
+			target := decoder.entityId()
+			if decoder.err != nil {
+				break
+			}
+			if typeIndex >= uint64(len(types)) {
+				return nil, fmt.Errorf("%w: component type %d", ErrSnapshotNotValid, typeIndex)
+			}
+
+			componentIds[j] = ComponentId{
+				id:            world.componentRegistry.getId(types[typeIndex]),
+				componentType: types[typeIndex],
+				target:        target,
+			}
+		}
+
+		count := decoder.length(binaryEntityIdSize)
+		if decoder.err != nil {
+			break
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: empty archetype", ErrSnapshotNotValid)
+		}
+
+		sortedComponentIds := slices.Clone(componentIds)
+		sortComponentIds(sortedComponentIds)
+		if len(slices.Compact(slices.Clone(sortedComponentIds))) != len(sortedComponentIds) {
+			return nil, fmt.Errorf("%w: duplicate component in archetype", ErrSnapshotNotValid)
+		}
+		if slices.ContainsFunc(archetypes[:i], func(other binarySnapshotArchetype) bool {
+			return slices.Equal(other.sortedComponentIds, sortedComponentIds)
+		}) {
+			return nil, fmt.Errorf("%w: duplicate archetype", ErrSnapshotNotValid)
+		}
+
+		entities := make([]EntityId, count)
+		decoder.raw(unsafe.Pointer(&entities[0]), uintptr(count)*binaryEntityIdSize)
+		for _, entity := range entities {
+			if decoder.err != nil {
+				break
+			}
+
+			index := int(entity.index)
+			if index == 0 || index >= numberOfSlots || isAlive[index] == 0 || generations[index] != entity.generation || isRestored[index] {
+				return nil, fmt.Errorf("%w: entity %s", ErrSnapshotNotValid, entity)
+			}
+			isRestored[index] = true
+		}
+		numberOfRestoredEntities += count
+
+		storages := make([]binarySnapshotStorage, len(componentIds))
+		for j, componentId := range componentIds {
+			ticks := make([]componentTicks, count)
+			decoder.raw(unsafe.Pointer(&ticks[0]), uintptr(count)*binaryComponentTicksSize)
+
+			data := reflect.New(reflect.ArrayOf(count, componentId.componentType)).Elem()
+			if isPointerFree(componentId.componentType) {
+				decoder.raw(data.Addr().UnsafePointer(), uintptr(count)*componentId.componentType.Size())
+			} else {
+				for row := range count {
+					decoder.value(data.Index(row))
+				}
+			}
+
+			storages[j] = binarySnapshotStorage{
+				data:  data,
+				ticks: ticks,
+			}
+		}
+
+		archetypes[i] = binarySnapshotArchetype{
+			componentIds:       componentIds,
+			sortedComponentIds: sortedComponentIds,
+			entities:           entities,
+			storages:           storages,
+		}
+	}
+
+	if decoder.err == nil && numberOfRestoredEntities != numberOfEntities {
+		return nil, fmt.Errorf("%w: %d entities do not belong to an archetype", ErrSnapshotNotValid, numberOfEntities-numberOfRestoredEntities)
+	}
+
+	// resources
+	resources := make([]reflect.Value, decoder.length(1))
+	for i := range resources {
+		typeName := decoder.string()
+		if decoder.err != nil {
+			break
+		}
+
+		resourceType := GetResourceTypeByString(world, typeName)
+		if resourceType == nil {
+			return nil, fmt.Errorf("%w: resource %s", ErrSnapshotTypeNotRegistered, typeName)
+		}
+
+		resources[i] = reflect.New(resourceType)
+		decoder.value(resources[i].Elem())
+	}
+
+	if decoder.err != nil {
+		return nil, decoder.err
+	}
+	if decoder.offset != len(decoder.data) {
+		return nil, fmt.Errorf("%w: unexpected data after the end of the snapshot", ErrSnapshotNotValid)
+	}
+
+	return &binarySnapshot{
+		generations:      generations,
+		isAlive:          isAlive,
+		numberOfEntities: numberOfEntities,
+		freeIndices:      freeIndices,
+		archetypes:       archetypes,
+		resources:        resources,
+	}, nil
+}
+
+// snapshotComponentTypeByString returns the component type with the given name, including built-in components that
+// might not be registered yet. Returns nil if there is no such component type.
+func snapshotComponentTypeByString(world *World, typeName string) reflect.Type {
+	for _, builtInType := range []reflect.Type{reflect.TypeFor[Parent](), reflect.TypeFor[Children]()} {
+		if builtInType.String() == typeName {
+			return builtInType
+		}
+	}
+
+	return GetComponentTypeByString(world, typeName)
+}
+
+// isPointerFree returns whether values of valueType do not contain any pointers, so that they can be copied as raw
+// memory.
+func isPointerFree(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return valueType.Len() == 0 || isPointerFree(valueType.Elem())
+	case reflect.Struct:
+		for i := range valueType.NumField() {
+			if !isPointerFree(valueType.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// binaryEncoder appends values to a buffer in the binary snapshot format.
+type binaryEncoder struct {
+	buffer []byte
+
+	// pointers holds the pointers that are being encoded, so that pointer cycles can be detected.
+	pointers map[binaryPointer]struct{}
+}
+
+// binaryPointer identifies a pointer by its address and type, because the address of a struct is the same as the
+// address of its first field.
+type binaryPointer struct {
+	address     unsafe.Pointer
+	pointerType reflect.Type
+}
+
+// header writes the magic, version and memory layout that binary snapshots start with.
+func (encoder *binaryEncoder) header() {
+	encoder.bytes([]byte(binarySnapshotMagic))
+	encoder.uvarint(binarySnapshotVersion)
+	encoder.bytes([]byte{binarySnapshotLayout})
+}
+
+func (encoder *binaryEncoder) bytes(data []byte) {
+	encoder.buffer = append(encoder.buffer, data...)
+}
+
+func (encoder *binaryEncoder) raw(pointer unsafe.Pointer, size uintptr) {
+	if size == 0 {
+		return
+	}
+	encoder.buffer = append(encoder.buffer, unsafe.Slice((*byte)(pointer), size)...)
+}
+
+func (encoder *binaryEncoder) uvarint(value uint64) {
+	encoder.buffer = binary.AppendUvarint(encoder.buffer, value)
+}
+
+func (encoder *binaryEncoder) string(value string) {
+	encoder.uvarint(uint64(len(value)))
+	encoder.buffer = append(encoder.buffer, value...)
+}
+
+func (encoder *binaryEncoder) entityId(entity EntityId) {
+	encoder.uvarint(uint64(entity.index))
+	encoder.uvarint(uint64(entity.generation))
+}
+
+// value encodes value field by field. Unexported fields are encoded as well.
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotTypeNotSupported error when value contains a non-nil interface, channel, function,
+//     unsafe.Pointer or a pointer that points back to a value that contains it
+func (encoder *binaryEncoder) value(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			encoder.bytes([]byte{1})
+		} else {
+			encoder.bytes([]byte{0})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		encoder.buffer = binary.AppendVarint(encoder.buffer, value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		encoder.uvarint(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		encoder.buffer = binary.LittleEndian.AppendUint64(encoder.buffer, math.Float64bits(value.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		encoder.buffer = binary.LittleEndian.AppendUint64(encoder.buffer, math.Float64bits(real(value.Complex())))
+		encoder.buffer = binary.LittleEndian.AppendUint64(encoder.buffer, math.Float64bits(imag(value.Complex())))
+	case reflect.String:
+		encoder.string(value.String())
+	case reflect.Array:
+		if isPointerFree(value.Type().Elem()) && value.CanAddr() {
+			encoder.raw(value.Addr().UnsafePointer(), value.Type().Size())
+			return nil
+		}
+
+		for i := range value.Len() {
+			if err := encoder.value(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		// the length is increased by 1 so that 0 can be used to tell apart nil slices from empty slices
+		if value.IsNil() {
+			encoder.uvarint(0)
+			return nil
+		}
+		encoder.uvarint(uint64(value.Len()) + 1)
+
+		if isPointerFree(value.Type().Elem()) {
+			encoder.raw(value.UnsafePointer(), uintptr(value.Len())*value.Type().Elem().Size())
+			return nil
+		}
+
+		for i := range value.Len() {
+			if err := encoder.value(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			encoder.uvarint(0)
+			return nil
+		}
+		encoder.uvarint(uint64(value.Len()) + 1)
+
+		iterator := value.MapRange()
+		for iterator.Next() {
+			if err := encoder.value(iterator.Key()); err != nil {
+				return err
+			}
+			if err := encoder.value(iterator.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if value.IsNil() {
+			encoder.bytes([]byte{0})
+			return nil
+		}
+
+		pointer := binaryPointer{address: value.UnsafePointer(), pointerType: value.Type()}
+		if _, isEncoding := encoder.pointers[pointer]; isEncoding {
+			return fmt.Errorf("%w: pointer cycle through %s", ErrSnapshotTypeNotSupported, value.Type().String())
+		}
+		if encoder.pointers == nil {
+			encoder.pointers = map[binaryPointer]struct{}{}
+		}
+		encoder.pointers[pointer] = struct{}{}
+		defer delete(encoder.pointers, pointer)
+
+		encoder.bytes([]byte{1})
+		return encoder.value(value.Elem())
+	case reflect.Struct:
+		for i := range value.NumField() {
+			if err := encoder.value(value.Field(i)); err != nil {
+				return fmt.Errorf("field %s: %w", value.Type().Field(i).Name, err)
+			}
+		}
+	default:
+		// interfaces, channels, functions and unsafe pointers can not be restored, unless they are nil
+		if !value.IsNil() {
+			return fmt.Errorf("%w: %s", ErrSnapshotTypeNotSupported, value.Type().String())
+		}
+	}
+
+	return nil
+}
+
+// binaryDecoder reads values from a binary snapshot. Once reading fails, err is set and all following reads return
+// zero values.
+type binaryDecoder struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (decoder *binaryDecoder) fail(reason string) {
+	if decoder.err == nil {
+		decoder.err = fmt.Errorf("%w: %s at offset %d", ErrSnapshotNotValid, reason, decoder.offset)
+	}
+}
+
+// header reads the header that got written by [binaryEncoder.header].
+//
+// Can return the following errors:
+//   - Returns an ErrSnapshotNotValid error when the header is missing, or when the data got created by another
+//     version of this package or on a machine with another memory layout
+func (decoder *binaryDecoder) header() error {
+	if string(decoder.bytes(len(binarySnapshotMagic))) != binarySnapshotMagic {
+		return fmt.Errorf("%w: missing header", ErrSnapshotNotValid)
+	}
+	if version := decoder.uvarint(); version != binarySnapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrSnapshotNotValid, version)
+	}
+	if layout := decoder.bytes(1); len(layout) == 1 && layout[0] != binarySnapshotLayout {
+		return fmt.Errorf("%w: created on a machine with another memory layout", ErrSnapshotNotValid)
+	}
+
+	return decoder.err
+}
+
+func (decoder *binaryDecoder) bytes(size int) []byte {
+	if decoder.err != nil {
+		return nil
+	}
+	if size < 0 || size > len(decoder.data)-decoder.offset {
+		decoder.fail("unexpected end of data")
+		return nil
+	}
+
+	result := decoder.data[decoder.offset : decoder.offset+size]
+	decoder.offset += size
+	return result
+}
+
+func (decoder *binaryDecoder) raw(pointer unsafe.Pointer, size uintptr) {
+	if size == 0 {
+		return
+	}
+
+	data := decoder.bytes(int(size))
+	if data != nil {
+		copy(unsafe.Slice((*byte)(pointer), size), data)
+	}
+}
+
+func (decoder *binaryDecoder) uvarint() uint64 {
+	if decoder.err != nil {
+		return 0
+	}
+
+	value, size := binary.Uvarint(decoder.data[decoder.offset:])
+	if size <= 0 {
+		decoder.fail("invalid number")
+		return 0
+	}
+
+	decoder.offset += size
+	return value
+}
+
+func (decoder *binaryDecoder) varint() int64 {
+	if decoder.err != nil {
+		return 0
+	}
+
+	value, size := binary.Varint(decoder.data[decoder.offset:])
+	if size <= 0 {
+		decoder.fail("invalid number")
+		return 0
+	}
+
+	decoder.offset += size
+	return value
+}
+
+// length reads the number of elements that follow, of which each takes up at least minimumSize bytes. It fails when
+// there are not enough bytes left, so that invalid data can not cause huge allocations.
+func (decoder *binaryDecoder) length(minimumSize uintptr) int {
+	length := decoder.uvarint()
+	if minimumSize > 0 && length > uint64(len(decoder.data)-decoder.offset)/uint64(minimumSize) {
+		decoder.fail("length exceeds the size of the data")
+		return 0
+	}
+
+	return int(length)
+}
+
+func (decoder *binaryDecoder) string() string {
+	return string(decoder.bytes(decoder.length(1)))
+}
+
+func (decoder *binaryDecoder) entityId() EntityId {
+	return EntityId{
+		index:      uint32(decoder.uvarint()),
+		generation: uint32(decoder.uvarint()),
+	}
+}
+
+// value decodes a value that got encoded by [binaryEncoder.value] in to value, which must be addressable.
+func (decoder *binaryDecoder) value(value reflect.Value) {
+	if decoder.err != nil {
+		return
+	}
+
+	if !value.CanSet() {
+		// unexported fields are restored as well
+		value = reflect.NewAt(value.Type(), value.Addr().UnsafePointer()).Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		data := decoder.bytes(1)
+		value.SetBool(len(data) == 1 && data[0] == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(decoder.varint())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value.SetUint(decoder.uvarint())
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(decoder.float())
+	case reflect.Complex64, reflect.Complex128:
+		value.SetComplex(complex(decoder.float(), decoder.float()))
+	case reflect.String:
+		value.SetString(decoder.string())
+	case reflect.Array:
+		if isPointerFree(value.Type().Elem()) {
+			decoder.raw(value.Addr().UnsafePointer(), value.Type().Size())
+			return
+		}
+
+		for i := range value.Len() {
+			decoder.value(value.Index(i))
+		}
+	case reflect.Slice:
+		length := decoder.length(0)
+		if length == 0 {
+			value.SetZero()
+			return
+		}
+		length--
+
+		elementType := value.Type().Elem()
+		if elementType.Size() > 0 && uint64(length) > uint64(len(decoder.data)-decoder.offset) {
+			decoder.fail("length exceeds the size of the data")
+			return
+		}
+
+		value.Set(reflect.MakeSlice(value.Type(), length, length))
+		if isPointerFree(elementType) {
+			decoder.raw(value.UnsafePointer(), uintptr(length)*elementType.Size())
+			return
+		}
+
+		for i := range length {
+			decoder.value(value.Index(i))
+		}
+	case reflect.Map:
+		length := decoder.length(0)
+		if length == 0 {
+			value.SetZero()
+			return
+		}
+		length--
+
+		if uint64(length) > uint64(len(decoder.data)-decoder.offset) {
+			decoder.fail("length exceeds the size of the data")
+			return
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), length)
+		for range length {
+			key := reflect.New(value.Type().Key()).Elem()
+			decoder.value(key)
+			element := reflect.New(value.Type().Elem()).Elem()
+			decoder.value(element)
+			if decoder.err != nil {
+				return
+			}
+			result.SetMapIndex(key, element)
+		}
+		value.Set(result)
+	case reflect.Pointer:
+		data := decoder.bytes(1)
+		if len(data) == 0 || data[0] == 0 {
+			value.SetZero()
+			return
+		}
+
+		element := reflect.New(value.Type().Elem())
+		decoder.value(element.Elem())
+		value.Set(element)
+	case reflect.Struct:
+		for i := range value.NumField() {
+			decoder.value(value.Field(i))
+		}
+	default:
+		value.SetZero()
+	}
+}
+
+func (decoder *binaryDecoder) float() float64 {
+	data := decoder.bytes(8)
+	if data == nil {
+		return 0
+	}
+
+	return math.Float64frombits(binary.LittleEndian.Uint64(data))
+}
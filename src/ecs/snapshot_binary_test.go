@@ -0,0 +1,284 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotBinary(t *testing.T) {
+	type binaryPosition struct {
+		Component
+		X, Y float32
+	}
+	type binaryInventory struct {
+		Component
+		Name  string
+		items []string
+		Count map[string]int
+		Owner *EntityId
+		Empty []int
+	}
+	type binaryTag struct{ Component }
+	type binaryTargets struct {
+		Relation
+		Damage int
+	}
+	type binaryScore struct {
+		Points int
+		name   string
+	}
+
+	registerTypes := func(world *World) {
+		RegisterComponent[binaryPosition](world)
+		RegisterComponent[binaryInventory](world)
+		RegisterComponent[binaryTag](world)
+		RegisterComponent[binaryTargets](world)
+		RegisterResource[binaryScore](world)
+	}
+
+	t.Run("restores entities with their ids, components and resources", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		empty, err := Spawn(world)
+		assert.NoError(err)
+		a, err := Spawn(world, &binaryPosition{X: 1, Y: 2}, &binaryTag{})
+		assert.NoError(err)
+		b, err := Spawn(world, &binaryInventory{
+			Name:  "chest",
+			items: []string{"sword", "shield"},
+			Count: map[string]int{"gold": 3},
+			Owner: &a,
+			Empty: []int{},
+		})
+		assert.NoError(err)
+		assert.NoError(world.Resources().Add(&binaryScore{Points: 7, name: "high score"}))
+
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		registerTypes(restored)
+		assert.NoError(RestoreBinary(restored, data))
+		assert.Equal(3, restored.CountEntities())
+
+		hasTag, err := HasComponent[binaryTag](restored, empty)
+		assert.NoError(err)
+		assert.False(hasTag)
+
+		position, err := Get1[binaryPosition](restored, a)
+		assert.NoError(err)
+		assert.Equal(float32(1), position.X)
+		assert.Equal(float32(2), position.Y)
+
+		inventory, err := Get1[binaryInventory](restored, b)
+		assert.NoError(err)
+		assert.Equal("chest", inventory.Name)
+		assert.Equal([]string{"sword", "shield"}, inventory.items)
+		assert.Equal(map[string]int{"gold": 3}, inventory.Count)
+		assert.Equal(a, *inventory.Owner)
+		assert.NotNil(inventory.Empty)
+		assert.Empty(inventory.Empty)
+
+		score, err := GetResource[binaryScore](restored)
+		assert.NoError(err)
+		assert.Equal(7, score.Points)
+		assert.Equal("high score", score.name)
+	})
+
+	t.Run("restores the hierarchy and relations", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world)
+		assert.NoError(err)
+		child, err := Spawn(world, &Parent{Entity: parent})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, parent, child, &binaryTargets{Damage: 5}))
+
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		registerTypes(restored)
+		assert.NoError(RestoreBinary(restored, data))
+
+		children, err := Get1[Children](restored, parent)
+		assert.NoError(err)
+		assert.Equal([]EntityId{child}, children.Entities())
+
+		relation, err := GetRelation[binaryTargets](restored, parent, child)
+		assert.NoError(err)
+		assert.Equal(5, relation.Damage)
+
+		sources, err := RelationSources[binaryTargets](restored, child)
+		assert.NoError(err)
+		assert.Equal([]EntityId{parent}, sources)
+	})
+
+	t.Run("rolls back the world it got created from", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		a, err := Spawn(world, &binaryPosition{X: 1})
+		assert.NoError(err)
+		b, err := Spawn(world, &binaryPosition{X: 2})
+		assert.NoError(err)
+		despawned, err := Spawn(world, &binaryTag{})
+		assert.NoError(err)
+		assert.NoError(Despawn(world, despawned))
+
+		query := Query1[binaryPosition, Default]{}
+		assert.NoError(query.Prepare(world, nil))
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(2), query.NumberOfResult())
+
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		// change the world after taking the snapshot
+		assert.NoError(Despawn(world, a))
+		position, err := Get1[*binaryPosition](world, b)
+		assert.NoError(err)
+		position.X = 20
+		_, err = Spawn(world, &binaryPosition{X: 3}, &binaryTag{})
+		assert.NoError(err)
+		_, err = Spawn(world, &binaryTag{})
+		assert.NoError(err)
+
+		assert.NoError(RestoreBinary(world, data))
+		assert.Equal(2, world.CountEntities())
+		assert.Equal(2, world.CountComponents())
+
+		restoredPosition, err := Get1[binaryPosition](world, a)
+		assert.NoError(err)
+		assert.Equal(float32(1), restoredPosition.X)
+		restoredPosition, err = Get1[binaryPosition](world, b)
+		assert.NoError(err)
+		assert.Equal(float32(2), restoredPosition.X)
+
+		assert.NoError(query.Exec(world))
+		assert.Equal(uint(2), query.NumberOfResult())
+
+		// the slot of the despawned entity is reused just like it would be before restoring
+		respawned, err := Spawn(world)
+		assert.NoError(err)
+		assert.Equal(despawned.Index(), respawned.Index())
+		assert.Equal(despawned.Generation()+1, respawned.Generation())
+	})
+
+	t.Run("keeps entity observers of entities that still exist", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &binaryTag{})
+		assert.NoError(err)
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		observers := &observerRegistry{}
+		entityData, err := world.entities.get(entity)
+		assert.NoError(err)
+		entityData.observers = observers
+
+		assert.NoError(RestoreBinary(world, data))
+
+		entityData, err = world.entities.get(entity)
+		assert.NoError(err)
+		assert.Same(observers, entityData.observers)
+	})
+
+	t.Run("returns an error when a type is not registered", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Spawn(world, &binaryPosition{})
+		assert.NoError(err)
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		assert.ErrorIs(RestoreBinary(restored, data), ErrSnapshotTypeNotRegistered)
+	})
+
+	t.Run("returns an error when a component can not be stored", func(t *testing.T) {
+		type withFunc struct {
+			Component
+			callback func()
+		}
+
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Spawn(world, &withFunc{callback: func() {}})
+		assert.NoError(err)
+
+		_, err = SnapshotBinary(world)
+		assert.ErrorIs(err, ErrSnapshotTypeNotSupported)
+	})
+
+	t.Run("returns an error when a component contains a pointer cycle", func(t *testing.T) {
+		type binaryNode struct {
+			Component
+			Value int
+			Next  *binaryNode
+		}
+
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		// pointers that are shared without forming a cycle can be stored
+		shared := &binaryNode{Value: 1}
+		_, err := Spawn(world, &binaryNode{Next: &binaryNode{Next: shared}})
+		assert.NoError(err)
+		_, err = Spawn(world, &binaryNode{Next: shared})
+		assert.NoError(err)
+		_, err = SnapshotBinary(world)
+		assert.NoError(err)
+
+		node := &binaryNode{}
+		node.Next = node
+		_, err = Spawn(world, node)
+		assert.NoError(err)
+		_, err = SnapshotBinary(world)
+		assert.ErrorIs(err, ErrSnapshotTypeNotSupported)
+	})
+
+	t.Run("returns an error and leaves the world untouched when the snapshot is not valid", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		registerTypes(world)
+
+		_, err := Spawn(world, &binaryInventory{Name: "chest", items: []string{"sword"}})
+		assert.NoError(err)
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		entity, err := Spawn(world, &binaryPosition{X: 1})
+		assert.NoError(err)
+
+		assert.ErrorIs(RestoreBinary(world, []byte("not a snapshot")), ErrSnapshotNotValid)
+		for length := range len(data) {
+			assert.ErrorIs(RestoreBinary(world, data[:length]), ErrSnapshotNotValid)
+		}
+		assert.ErrorIs(RestoreBinary(world, append(data, 0)), ErrSnapshotNotValid)
+
+		assert.Equal(2, world.CountEntities())
+		position, err := Get1[binaryPosition](world, entity)
+		assert.NoError(err)
+		assert.Equal(float32(1), position.X)
+	})
+
+	t.Run("returns an error while querying", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		data, err := SnapshotBinary(world)
+		assert.NoError(err)
+
+		world.startQuerying()
+		defer world.stopQuerying()
+		assert.ErrorIs(RestoreBinary(world, data), ErrWorldIsLocked)
+	})
+}
@@ -0,0 +1,242 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotJSON(t *testing.T) {
+	type snapshotPosition struct {
+		Component
+		X, Y int
+	}
+	type snapshotFollower struct {
+		Component
+		Leader    EntityId
+		Friends   []EntityId
+		Distances map[EntityId]int
+		Nested    struct{ Target *EntityId }
+	}
+	type snapshotTargets struct {
+		Relation
+		Damage int
+	}
+	type snapshotScore struct {
+		Points int
+		Best   EntityId
+	}
+
+	registerTypes := func(world *World) {
+		RegisterComponent[snapshotPosition](world)
+		RegisterComponent[snapshotFollower](world)
+		RegisterComponent[snapshotTargets](world)
+		RegisterResource[snapshotScore](world)
+	}
+
+	t.Run("restores components and resources", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entity, err := Spawn(world, &snapshotPosition{X: 1, Y: 2})
+		assert.NoError(err)
+		assert.NoError(world.Resources().Add(&snapshotScore{Points: 10, Best: entity}))
+
+		data, err := SnapshotJSON(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		registerTypes(restored)
+		entityMap, err := RestoreJSON(restored, data)
+		assert.NoError(err)
+		assert.Equal(1, restored.CountEntities())
+
+		position, err := Get1[snapshotPosition](restored, entityMap[entity])
+		assert.NoError(err)
+		assert.Equal(1, position.X)
+		assert.Equal(2, position.Y)
+
+		score, err := GetResource[snapshotScore](restored)
+		assert.NoError(err)
+		assert.Equal(10, score.Points)
+		assert.Equal(entityMap[entity], score.Best)
+	})
+
+	t.Run("rewrites entity ids to the new entity ids", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		leader, err := Spawn(world)
+		assert.NoError(err)
+		friend, err := Spawn(world)
+		assert.NoError(err)
+		follower, err := Spawn(world, &snapshotFollower{
+			Leader:    leader,
+			Friends:   []EntityId{friend, leader},
+			Distances: map[EntityId]int{friend: 3},
+			Nested:    struct{ Target *EntityId }{Target: &friend},
+		})
+		assert.NoError(err)
+
+		data, err := SnapshotJSON(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		registerTypes(restored)
+		// occupy the first slots so that the restored entities get different ids
+		_, err = SpawnBatch(restored, 5)
+		assert.NoError(err)
+
+		entityMap, err := RestoreJSON(restored, data)
+		assert.NoError(err)
+		assert.NotEqual(leader, entityMap[leader])
+
+		result, err := Get1[snapshotFollower](restored, entityMap[follower])
+		assert.NoError(err)
+		assert.Equal(entityMap[leader], result.Leader)
+		assert.Equal([]EntityId{entityMap[friend], entityMap[leader]}, result.Friends)
+		assert.Equal(map[EntityId]int{entityMap[friend]: 3}, result.Distances)
+		assert.Equal(entityMap[friend], *result.Nested.Target)
+	})
+
+	t.Run("rewrites entity ids in interfaces", func(t *testing.T) {
+		assert := assert.New(t)
+		from := EntityId{index: 1}
+		to := EntityId{index: 2}
+		target := from
+
+		value := struct {
+			Entity  any
+			Nested  any
+			Pointer any
+		}{
+			Entity:  from,
+			Nested:  struct{ Target EntityId }{Target: from},
+			Pointer: &target,
+		}
+		remapEntityIds(reflect.ValueOf(&value).Elem(), map[EntityId]EntityId{from: to})
+
+		assert.Equal(to, value.Entity)
+		assert.Equal(struct{ Target EntityId }{Target: to}, value.Nested)
+		assert.Equal(to, target)
+	})
+
+	t.Run("entity ids outside of the snapshot become the zero value", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		follower, err := Spawn(world, &snapshotFollower{Leader: EntityId{index: 100}})
+		assert.NoError(err)
+
+		data, err := SnapshotJSON(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		registerTypes(restored)
+		entityMap, err := RestoreJSON(restored, data)
+		assert.NoError(err)
+
+		result, err := Get1[snapshotFollower](restored, entityMap[follower])
+		assert.NoError(err)
+		assert.Equal(nonExistingEntity, result.Leader)
+	})
+
+	t.Run("restores the hierarchy and relations", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world)
+		assert.NoError(err)
+		child, err := Spawn(world, &Parent{Entity: parent})
+		assert.NoError(err)
+		assert.NoError(InsertRelation(world, parent, child, &snapshotTargets{Damage: 5}))
+
+		data, err := SnapshotJSON(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		registerTypes(restored)
+		entityMap, err := RestoreJSON(restored, data)
+		assert.NoError(err)
+
+		children, err := Get1[Children](restored, entityMap[parent])
+		assert.NoError(err)
+		assert.Equal([]EntityId{entityMap[child]}, children.Entities())
+
+		relation, err := GetRelation[snapshotTargets](restored, entityMap[parent], entityMap[child])
+		assert.NoError(err)
+		assert.Equal(5, relation.Damage)
+	})
+
+	t.Run("overwrites resources that are already present", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		assert.NoError(world.Resources().Add(&snapshotScore{Points: 10}))
+
+		data, err := SnapshotJSON(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		existing := &snapshotScore{Points: 1}
+		assert.NoError(restored.Resources().Add(existing))
+
+		_, err = RestoreJSON(restored, data)
+		assert.NoError(err)
+		assert.Equal(10, existing.Points)
+	})
+
+	t.Run("returns an error when a type is not registered", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Spawn(world, &snapshotPosition{})
+		assert.NoError(err)
+
+		data, err := SnapshotJSON(world)
+		assert.NoError(err)
+
+		restored := NewDefaultWorld()
+		_, err = RestoreJSON(restored, data)
+		assert.ErrorIs(err, ErrSnapshotTypeNotRegistered)
+		assert.Equal(0, restored.CountEntities())
+	})
+
+	t.Run("returns an error when the snapshot is not valid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := RestoreJSON(NewDefaultWorld(), []byte("not json"))
+		assert.Error(err)
+	})
+
+	t.Run("returns an error when a component can not be encoded", func(t *testing.T) {
+		type withChannel struct {
+			Component
+			Channel chan int
+		}
+
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := Spawn(world, &withChannel{Channel: make(chan int)})
+		assert.NoError(err)
+
+		_, err = SnapshotJSON(world)
+		assert.Error(err)
+	})
+}
+
+func TestEntityIdText(t *testing.T) {
+	assert := assert.New(t)
+
+	entity := EntityId{index: 3, generation: 7}
+	data, err := entity.MarshalText()
+	assert.NoError(err)
+	assert.Equal("3:7", string(data))
+
+	var result EntityId
+	assert.NoError(result.UnmarshalText(data))
+	assert.Equal(entity, result)
+
+	assert.Error(result.UnmarshalText([]byte("invalid")))
+}
@@ -1,8 +1,10 @@
 package ecs
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/lucdrenth/murphecs/src/utils"
 )
@@ -12,18 +14,93 @@ import (
 //
 // Can return the following errors:
 //   - Returns an ErrComponentIsNil error when any of the given components is nil
+//   - Returns an ErrComponentIsRelation error when any of the given components is a [Relation]
 //   - Returns an ErrDuplicateComponent error when any of the given components are of the same type.
+//   - Returns an ErrParentNotFound error when the entity of a [Parent] component does not exist.
 //   - Returns an ErrWorldIsLocked error while querying
 func Spawn(world *World, components ...AnyComponent) (EntityId, error) {
-	for i, component := range components {
-		if component == nil {
-			return nonExistingEntity, fmt.Errorf("%w: at position %d", ErrComponentIsNil, i+1)
-		}
+	return spawn(world, nonExistingEntity, components)
+}
+
+// spawnReserved spawns an entity that got reserved with [entityStorage.reserve], such as entities that are
+// spawned using [Commands].
+//
+// Can return the same errors as [Spawn], and:
+//   - Returns an ErrEntityNotReserved error when entity is not reserved
+func spawnReserved(world *World, entity EntityId, components ...AnyComponent) error {
+	_, err := spawn(world, entity, components)
+	return err
+}
+
+// SpawnBatch spawns count entities that each get a copy of the given components and all their required components
+// that are not declared in the component parameters. Returns the entityIds of the newly created entities.
+//
+// The archetype of the entities is resolved only once and each component storage grows at most once, which makes
+// this a lot faster than calling [Spawn] count times. Spawn observers are still triggered for every entity.
+//
+// Components are copied shallowly, so reference types such as slices and maps are shared between the entities.
+//
+// Can return the following errors:
+//   - Returns an ErrComponentIsNil error when any of the given components is nil
+//   - Returns an ErrComponentIsRelation error when any of the given components is a [Relation]
+//   - Returns an ErrDuplicateComponent error when any of the given components are of the same type.
+//   - Returns an ErrParentNotFound error when the entity of a [Parent] component does not exist.
+//   - Returns an ErrWorldIsLocked error while querying
+//   - Returns an error when the components of an entity could not be stored. The entities that got spawned before it
+//     are returned along with the error.
+func SpawnBatch(world *World, count uint, components ...AnyComponent) ([]EntityId, error) {
+	if world.isQuerying() {
+		// If we allow this, the newly spawned entities may or may not be included in the query results, which is unpredictable.
+		return nil, ErrWorldIsLocked
+	}
+
+	layout, err := newSpawnLayout(world, components)
+	if err != nil {
+		return nil, err
 	}
 
-	if world.isQuerying {
+	return layout.spawnBatch(world, count)
+}
+
+// spawn spawns an entity with the given components. If reservedEntity is not nonExistingEntity, the reserved entity
+// is spawned instead of a new one.
+func spawn(world *World, reservedEntity EntityId, components []AnyComponent) (EntityId, error) {
+	if world.isQuerying() {
 		// If we allow this, this newly spawned entity may or may not be included in the query results, which is unpredictable.
-		return 0, ErrWorldIsLocked
+		return nonExistingEntity, ErrWorldIsLocked
+	}
+
+	if reservedEntity != nonExistingEntity && !world.entities.isReserved(reservedEntity) {
+		return nonExistingEntity, fmt.Errorf("%w: %s", ErrEntityNotReserved, reservedEntity)
+	}
+
+	layout, err := newSpawnLayout(world, components)
+	if err != nil {
+		return nonExistingEntity, err
+	}
+
+	return layout.spawn(world, reservedEntity)
+}
+
+// spawnLayout is a validated set of components together with the archetype and the component storages that
+// they get spawned in to. A spawnLayout can be used to spawn any number of entities.
+type spawnLayout struct {
+	archetype       *Archetype
+	componentIds    []ComponentId       // the components and the required components
+	componentValues []reflect.Value     // pointers to the components and the required components
+	storages        []*componentStorage // storages[i] is the storage of componentValues[i]
+	parentChange    parentChange
+}
+
+// newSpawnLayout validates components, resolves their required components and gets their archetype.
+func newSpawnLayout(world *World, components []AnyComponent) (spawnLayout, error) {
+	for i, component := range components {
+		if component == nil {
+			return spawnLayout{}, fmt.Errorf("%w: at position %d", ErrComponentIsNil, i+1)
+		}
+		if _, isRelation := component.(AnyRelation); isRelation {
+			return spawnLayout{}, fmt.Errorf("%w: at position %d", ErrComponentIsRelation, i+1)
+		}
 	}
 
 	componentIds := toComponentIds(components, world)
@@ -32,12 +109,17 @@ func Spawn(world *World, components ...AnyComponent) (EntityId, error) {
 	duplicate, duplicateIndexA, duplicateIndexB := utils.GetFirstDuplicate(componentIds)
 	if duplicate != nil {
 		debugType := ComponentDebugStringOf(components[duplicateIndexA])
-		return nonExistingEntity, fmt.Errorf("%w: %s at positions %d and %d", ErrComponentDuplicate, debugType, duplicateIndexA, duplicateIndexB)
+		return spawnLayout{}, fmt.Errorf("%w: %s at positions %d and %d", ErrComponentDuplicate, debugType, duplicateIndexA, duplicateIndexB)
+	}
+
+	parentChange, err := prepareParentChange(world, nonExistingEntity, components)
+	if err != nil {
+		return spawnLayout{}, err
 	}
 
 	// get required components
 	requiredComponents := getAllRequiredComponents(&componentIds, components, world)
-	components = append(components, requiredComponents...)
+	components = slices.Concat(components, requiredComponents)
 
 	// collect reflect.Value's. If any component is not a pointer, convert it to a pointer.
 	componentValues := make([]reflect.Value, len(components))
@@ -53,37 +135,103 @@ func Spawn(world *World, components ...AnyComponent) (EntityId, error) {
 		componentValues[i] = componentValue
 	}
 
-	// spawn components
-	entityId := world.generateEntityId()
-
-	var returnedErr error = nil
-
 	archetype, err := world.archetypeStorage.getArchetype(world, componentIds)
 	if err != nil {
-		return nonExistingEntity, err
+		return spawnLayout{}, err
 	}
 
-	var row uint
+	storages := make([]*componentStorage, len(components))
 	for i, component := range components {
 		// We can not reuse componentIds because it is not in the same order as components
-		componentId := ComponentIdOf(component, world)
+		storages[i] = archetype.components[ComponentIdOf(component, world)]
+	}
+
+	return spawnLayout{
+		archetype:       archetype,
+		componentIds:    componentIds,
+		componentValues: componentValues,
+		storages:        storages,
+		parentChange:    parentChange,
+	}, nil
+}
 
-		storage := archetype.components[componentId]
-		row, err = storage.insertValue(world, &componentValues[i])
+// spawn spawns a single entity. If reservedEntity is not nonExistingEntity, the reserved entity is spawned
+// instead of a new one.
+func (layout *spawnLayout) spawn(world *World, reservedEntity EntityId) (EntityId, error) {
+	for i, storage := range layout.storages {
+		_, err := storage.insertValue(world, &layout.componentValues[i])
 		if err != nil {
-			return nonExistingEntity, fmt.Errorf("failed to insert component %s in to component registry: %w", componentId.DebugString(), err)
+			return nonExistingEntity, fmt.Errorf("failed to insert component %s in to component registry: %w", storage.componentId.DebugString(), err)
 		}
 	}
 
-	world.archetypeStorage.entityIdToArchetype[entityId] = archetype
-	archetype.entities = append(archetype.entities, entityId)
-
-	world.entities[entityId] = &EntityData{
-		row:       row,
-		archetype: archetype,
+	entityId := reservedEntity
+	var entityData *EntityData
+	if reservedEntity == nonExistingEntity {
+		entityId, entityData = world.entities.create()
+	} else {
+		var err error
+		entityData, err = world.entities.createReserved(reservedEntity)
+		if err != nil {
+			return nonExistingEntity, err
+		}
 	}
+	entityData.archetype = layout.archetype
+	entityData.row = layout.archetype.addEntity(entityId)
 
-	world.observers.triggerSpawnObservers(world, componentIds, entityId)
+	returnedErr := layout.parentChange.apply(world, entityId)
+
+	world.observers.triggerSpawnObservers(world, layout.componentIds, entityId)
 
 	return entityId, returnedErr
 }
+
+// spawnBatch spawns count entities. Spawn observers are triggered once all entities are spawned.
+//
+// If inserting the components of an entity fails, no more entities are spawned. The entities that did get spawned are
+// kept and finished just like when spawning succeeds: they get their parent and their spawn observers are triggered.
+// They are returned together with the error.
+func (layout *spawnLayout) spawnBatch(world *World, count uint) ([]EntityId, error) {
+	for _, storage := range layout.storages {
+		storage.reserve(count)
+	}
+	layout.archetype.entities = slices.Grow(layout.archetype.entities, int(count))
+
+	var returnedErr error
+	entities := make([]EntityId, 0, count)
+	for range count {
+		isInserted := true
+		for i, storage := range layout.storages {
+			_, err := storage.insertValue(world, &layout.componentValues[i])
+			if err != nil {
+				// Roll back the components of this entity that did get inserted, to keep the storages in line with the archetype.
+				for _, insertedStorage := range layout.storages[:i] {
+					_, _ = insertedStorage.remove(insertedStorage.nextItemIndex - 1)
+				}
+				returnedErr = fmt.Errorf("failed to insert component %s in to component registry: %w", storage.componentId.DebugString(), err)
+				isInserted = false
+				break
+			}
+		}
+		if !isInserted {
+			break
+		}
+
+		entityId, entityData := world.entities.create()
+		entityData.archetype = layout.archetype
+		entityData.row = layout.archetype.addEntity(entityId)
+		entities = append(entities, entityId)
+	}
+
+	for _, entityId := range entities {
+		if err := layout.parentChange.apply(world, entityId); err != nil {
+			returnedErr = errors.Join(returnedErr, err)
+		}
+	}
+
+	for _, entityId := range entities {
+		world.observers.triggerSpawnObservers(world, layout.componentIds, entityId)
+	}
+
+	return entities, returnedErr
+}
@@ -76,22 +76,22 @@ func TestSpawn(t *testing.T) {
 
 		entity, err := Spawn(world)
 		assert.NoError(err)
-		assert.Equal(entity, EntityId(1))
+		assert.Equal(entity, EntityId{index: 1})
 		entity, err = Spawn(world, &componentA{})
 		assert.NoError(err)
-		assert.Equal(entity, EntityId(2))
+		assert.Equal(entity, EntityId{index: 2})
 		entity, err = Spawn(world, &componentA{})
 		assert.NoError(err)
-		assert.Equal(entity, EntityId(3))
+		assert.Equal(entity, EntityId{index: 3})
 		entity, err = Spawn(world, &componentB{})
 		assert.NoError(err)
-		assert.Equal(entity, EntityId(4))
+		assert.Equal(entity, EntityId{index: 4})
 		entity, err = Spawn(world, &componentA{}, &componentB{})
 		assert.NoError(err)
-		assert.Equal(entity, EntityId(5))
+		assert.Equal(entity, EntityId{index: 5})
 		entity, err = Spawn(world, &componentB{}, &componentA{})
 		assert.NoError(err)
-		assert.Equal(entity, EntityId(6))
+		assert.Equal(entity, EntityId{index: 6})
 
 		assert.Equal(6, world.CountEntities())
 		assert.Equal(7, world.CountComponents())
@@ -128,3 +128,150 @@ func TestSpawnWithRequiredComponents(t *testing.T) {
 		assert.NoError(err)
 	})
 }
+
+func TestSpawnBatch(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct{ Component }
+
+	t.Run("spawns count entities that each get a copy of the components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entities, err := SpawnBatch(world, 100, &componentA{value: 5}, componentB{})
+		assert.NoError(err)
+		assert.Len(entities, 100)
+		assert.Equal(100, world.CountEntities())
+		assert.Equal(200, world.CountComponents())
+
+		a, err := Get1[*componentA](world, entities[0])
+		assert.NoError(err)
+		a.value = 6
+
+		a, err = Get1[*componentA](world, entities[99])
+		assert.NoError(err)
+		assert.Equal(5, a.value)
+	})
+
+	t.Run("spawns required components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entities, err := SpawnBatch(world, 3, &withRequiredComponents{})
+		assert.NoError(err)
+		assert.Len(entities, 3)
+		assert.Equal(9, world.CountComponents())
+
+		_, _, err = Get2[requiredComponentA, requiredComponentB](world, entities[2])
+		assert.NoError(err)
+	})
+
+	t.Run("entities can be spawned in between other entities of the same archetype", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		entityA, err := Spawn(world, &componentA{value: 1})
+		assert.NoError(err)
+		entities, err := SpawnBatch(world, 2, &componentA{value: 2})
+		assert.NoError(err)
+		entityB, err := Spawn(world, &componentA{value: 3})
+		assert.NoError(err)
+
+		for i, entity := range []EntityId{entityA, entities[0], entities[1], entityB} {
+			a, err := Get1[componentA](world, entity)
+			assert.NoError(err)
+			assert.Equal([]int{1, 2, 2, 3}[i], a.value)
+		}
+	})
+
+	t.Run("triggers spawn observers for every entity", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		observed := []EntityId{}
+		err := On[OnSpawn[componentA]](world, func(event OnSpawn[componentA]) {
+			observed = append(observed, event.Entity)
+		})
+		assert.NoError(err)
+
+		entities, err := SpawnBatch(world, 3, &componentA{})
+		assert.NoError(err)
+		assert.Equal(entities, observed)
+	})
+
+	t.Run("adds the entities to the children of their parent", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parent, err := Spawn(world, &componentB{})
+		assert.NoError(err)
+		entities, err := SpawnBatch(world, 3, &Parent{Entity: parent})
+		assert.NoError(err)
+
+		children, err := Get1[Children](world, parent)
+		assert.NoError(err)
+		assert.Equal(entities, children.Entities())
+	})
+
+	t.Run("finishes the entities that got spawned before storing a component failed", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+		world.componentCapacityGrowthStrategy = &noComponentCapacityGrowth{}
+
+		parent, err := Spawn(world, &componentB{})
+		assert.NoError(err)
+		observed := []EntityId{}
+		err = On[OnSpawn[componentA]](world, func(event OnSpawn[componentA]) {
+			observed = append(observed, event.Entity)
+		})
+		assert.NoError(err)
+
+		// Storing a component can not fail once the storages are reserved, so the storage of componentA is used twice
+		// for each entity to run out of capacity halfway the batch.
+		layout, err := newSpawnLayout(world, []AnyComponent{&componentA{}, &Parent{Entity: parent}})
+		assert.NoError(err)
+		storage := layout.archetype.components[ComponentIdFor[componentA](world)]
+		layout.storages = append(layout.storages, storage)
+		layout.componentValues = append(layout.componentValues, layout.componentValues[0])
+		count := storage.capacity - storage.nextItemIndex
+		assert.Greater(count, uint(1))
+
+		entities, err := layout.spawnBatch(world, count)
+		assert.Error(err)
+		assert.Len(entities, int(count/2))
+		assert.Equal(entities, observed)
+
+		children, err := Get1[Children](world, parent)
+		assert.NoError(err)
+		assert.Equal(entities, children.Entities())
+	})
+
+	t.Run("returns an error for invalid components", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := SpawnBatch(world, 2, nil)
+		assert.ErrorIs(err, ErrComponentIsNil)
+		_, err = SpawnBatch(world, 2, &componentA{}, &componentA{})
+		assert.ErrorIs(err, ErrComponentDuplicate)
+		assert.Equal(0, world.CountEntities())
+	})
+
+	t.Run("returns an error while querying", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		world.startQuerying()
+		defer world.stopQuerying()
+		_, err := SpawnBatch(world, 2, &componentA{})
+		assert.ErrorIs(err, ErrWorldIsLocked)
+	})
+}
+
+type noComponentCapacityGrowth struct{}
+
+func (s *noComponentCapacityGrowth) GetExtraCapacity(currentCapacity uint) uint {
+	return 0
+}
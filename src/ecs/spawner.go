@@ -0,0 +1,135 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Spawner spawns entities with the components of a prototype. The component ids, the required components and the
+// archetype of the prototype are resolved once by [NewSpawner], so that spawning only has to copy the component
+// values in to the component storages.
+//
+// Components are copied shallowly, so reference types such as slices and maps are shared between the entities.
+//
+// A Spawner belongs to the world that it got created for and is not safe for concurrent use.
+type Spawner struct {
+	world  *World
+	layout spawnLayout
+
+	// componentIndices maps the type of each component of the prototype to its index in layout.componentValues
+	componentIndices map[reflect.Type]int
+}
+
+// NewSpawner returns a Spawner that spawns entities with the given components and all their required components that
+// are not declared in the component parameters.
+//
+// Can return the following errors:
+//   - Returns an ErrComponentIsNil error when any of the given components is nil
+//   - Returns an ErrComponentIsRelation error when any of the given components is a [Relation]
+//   - Returns an ErrDuplicateComponent error when any of the given components are of the same type.
+//   - Returns an ErrParentNotFound error when the entity of a [Parent] component does not exist.
+func NewSpawner(world *World, components ...AnyComponent) (*Spawner, error) {
+	layout, err := newSpawnLayout(world, slices.Clone(components))
+	if err != nil {
+		return nil, err
+	}
+
+	componentIndices := make(map[reflect.Type]int, len(layout.componentValues))
+	for i, componentValue := range layout.componentValues {
+		componentIndices[componentValue.Type().Elem()] = i
+	}
+
+	return &Spawner{
+		world:            world,
+		layout:           layout,
+		componentIndices: componentIndices,
+	}, nil
+}
+
+// Spawn spawns an entity with the components of the prototype. The given overrides replace the values of the
+// prototype components of the same type for this entity only.
+//
+// Can return the following errors:
+//   - Returns an ErrComponentIsNil error when any of the overrides is nil
+//   - Returns an ErrComponentNotFound error when the type of any of the overrides is not part of the prototype
+//   - Returns an ErrParentNotFound error when the entity of the [Parent] component does not exist (anymore).
+//   - Returns an ErrWorldIsLocked error while querying
+func (spawner *Spawner) Spawn(overrides ...AnyComponent) (EntityId, error) {
+	if spawner.world.isQuerying() {
+		// If we allow this, this newly spawned entity may or may not be included in the query results, which is unpredictable.
+		return nonExistingEntity, ErrWorldIsLocked
+	}
+
+	layout, err := spawner.layoutWithOverrides(overrides)
+	if err != nil {
+		return nonExistingEntity, err
+	}
+
+	return layout.spawn(spawner.world, nonExistingEntity)
+}
+
+// SpawnBatch spawns count entities with the components of the prototype. See [SpawnBatch].
+//
+// Can return the following errors:
+//   - Returns an ErrParentNotFound error when the entity of the [Parent] component does not exist (anymore).
+//   - Returns an ErrWorldIsLocked error while querying
+//   - Returns an error when the components of an entity could not be stored. The entities that got spawned before it
+//     are returned along with the error.
+func (spawner *Spawner) SpawnBatch(count uint) ([]EntityId, error) {
+	if spawner.world.isQuerying() {
+		// If we allow this, the newly spawned entities may or may not be included in the query results, which is unpredictable.
+		return nil, ErrWorldIsLocked
+	}
+
+	layout, err := spawner.layoutWithOverrides(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return layout.spawnBatch(spawner.world, count)
+}
+
+// layoutWithOverrides returns the layout of the spawner in which the component values are replaced by overrides.
+// The parent of the prototype, or of the overrides, is validated because it may have been despawned since.
+func (spawner *Spawner) layoutWithOverrides(overrides []AnyComponent) (spawnLayout, error) {
+	layout := spawner.layout
+
+	if len(overrides) > 0 {
+		layout.componentValues = slices.Clone(layout.componentValues)
+
+		for i, override := range overrides {
+			if override == nil {
+				return spawnLayout{}, fmt.Errorf("%w: at position %d", ErrComponentIsNil, i+1)
+			}
+
+			overrideValue := reflect.ValueOf(override)
+			overrideType := overrideValue.Type()
+			if overrideType.Kind() == reflect.Pointer {
+				overrideType = overrideType.Elem()
+			}
+
+			index, exists := spawner.componentIndices[overrideType]
+			if !exists {
+				return spawnLayout{}, fmt.Errorf("%w: %s is not part of the prototype", ErrComponentNotFound, ComponentDebugStringOf(override))
+			}
+
+			layout.componentValues[index] = overrideValue
+		}
+	}
+
+	if _, hasParent := findParent(overrides); hasParent || layout.parentChange.newParent != nonExistingEntity {
+		components := make([]AnyComponent, len(layout.componentValues))
+		for i, componentValue := range layout.componentValues {
+			components[i] = componentValue.Interface().(AnyComponent)
+		}
+
+		parentChange, err := prepareParentChange(spawner.world, nonExistingEntity, components)
+		if err != nil {
+			return spawnLayout{}, err
+		}
+		layout.parentChange = parentChange
+	}
+
+	return layout, nil
+}
@@ -0,0 +1,134 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpawner(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct{ Component }
+
+	t.Run("spawns entities with the components of the prototype", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		spawner, err := NewSpawner(world, &componentA{value: 1}, componentB{}, &withRequiredComponents{})
+		assert.NoError(err)
+
+		entityA, err := spawner.Spawn()
+		assert.NoError(err)
+		entityB, err := spawner.Spawn()
+		assert.NoError(err)
+		assert.Equal(2, world.CountEntities())
+		assert.Equal(10, world.CountComponents())
+
+		for _, entity := range []EntityId{entityA, entityB} {
+			a, _, _, err := Get3[componentA, componentB, requiredComponentA](world, entity)
+			assert.NoError(err)
+			assert.Equal(1, a.value)
+		}
+	})
+
+	t.Run("overrides replace prototype components for a single entity", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		spawner, err := NewSpawner(world, &componentA{value: 1}, &componentB{})
+		assert.NoError(err)
+
+		entityA, err := spawner.Spawn(&componentA{value: 2})
+		assert.NoError(err)
+		entityB, err := spawner.Spawn()
+		assert.NoError(err)
+
+		a, err := Get1[componentA](world, entityA)
+		assert.NoError(err)
+		assert.Equal(2, a.value)
+		a, err = Get1[componentA](world, entityB)
+		assert.NoError(err)
+		assert.Equal(1, a.value)
+	})
+
+	t.Run("returns an error for overrides that are not part of the prototype", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		spawner, err := NewSpawner(world, &componentA{})
+		assert.NoError(err)
+
+		_, err = spawner.Spawn(&componentB{})
+		assert.ErrorIs(err, ErrComponentNotFound)
+		_, err = spawner.Spawn(nil)
+		assert.ErrorIs(err, ErrComponentIsNil)
+		assert.Equal(0, world.CountEntities())
+	})
+
+	t.Run("returns an error for invalid prototypes", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		_, err := NewSpawner(world, &componentA{}, componentA{})
+		assert.ErrorIs(err, ErrComponentDuplicate)
+		_, err = NewSpawner(world, nil)
+		assert.ErrorIs(err, ErrComponentIsNil)
+	})
+
+	t.Run("spawns batches", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		spawner, err := NewSpawner(world, &componentA{value: 3})
+		assert.NoError(err)
+
+		entities, err := spawner.SpawnBatch(5)
+		assert.NoError(err)
+		assert.Len(entities, 5)
+
+		a, err := Get1[componentA](world, entities[4])
+		assert.NoError(err)
+		assert.Equal(3, a.value)
+	})
+
+	t.Run("validates the parent on every spawn", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		parentA, err := Spawn(world, &componentB{})
+		assert.NoError(err)
+		parentB, err := Spawn(world, &componentB{})
+		assert.NoError(err)
+
+		spawner, err := NewSpawner(world, &componentA{}, &Parent{Entity: parentA})
+		assert.NoError(err)
+
+		child, err := spawner.Spawn(&Parent{Entity: parentB})
+		assert.NoError(err)
+		children, err := Get1[Children](world, parentB)
+		assert.NoError(err)
+		assert.Equal([]EntityId{child}, children.Entities())
+
+		assert.NoError(Despawn(world, parentA))
+		_, err = spawner.Spawn()
+		assert.ErrorIs(err, ErrParentNotFound)
+	})
+
+	t.Run("returns an error while querying", func(t *testing.T) {
+		assert := assert.New(t)
+		world := NewDefaultWorld()
+
+		spawner, err := NewSpawner(world, &componentA{})
+		assert.NoError(err)
+
+		world.startQuerying()
+		defer world.stopQuerying()
+		_, err = spawner.Spawn()
+		assert.ErrorIs(err, ErrWorldIsLocked)
+		_, err = spawner.SpawnBatch(2)
+		assert.ErrorIs(err, ErrWorldIsLocked)
+	})
+}
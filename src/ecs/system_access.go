@@ -0,0 +1,132 @@
+package ecs
+
+import "reflect"
+
+type accessKind int
+
+const (
+	accessKindComponent accessKind = iota
+	accessKindResource
+	accessKindEventReader
+	accessKindEventWriter
+)
+
+// accessKey identifies data that systems can read or write.
+type accessKey struct {
+	kind         accessKind
+	isOuterWorld bool
+	worldId      WorldId      // only set if isOuterWorld is true
+	dataType     reflect.Type // never a pointer
+}
+
+func newAccessKey(kind accessKind, worldId *WorldId, dataType reflect.Type) accessKey {
+	if dataType.Kind() == reflect.Pointer {
+		dataType = dataType.Elem()
+	}
+
+	key := accessKey{kind: kind, dataType: dataType}
+	if worldId != nil {
+		key.isOuterWorld = true
+		key.worldId = *worldId
+	}
+
+	return key
+}
+
+// systemAccess describes the data that a system reads and writes, inferred from its params. It decides which
+// systems are allowed to run in parallel.
+type systemAccess struct {
+	// isExclusive is true if the system can access anything, for example because it has a *World param.
+	isExclusive bool
+
+	reads  map[accessKey]struct{}
+	writes map[accessKey]struct{}
+}
+
+func newSystemAccess() systemAccess {
+	return systemAccess{
+		reads:  map[accessKey]struct{}{},
+		writes: map[accessKey]struct{}{},
+	}
+}
+
+func (access *systemAccess) read(key accessKey) {
+	access.reads[key] = struct{}{}
+}
+
+func (access *systemAccess) write(key accessKey) {
+	access.writes[key] = struct{}{}
+}
+
+// addQuery adds the components of query. Components that are queried by pointer are written to, the others are read.
+// All components of zero-copy queries are written to, because their chunks give access to the component storages
+// and mark all of their components as changed, see [Query1.IterChunks].
+func (access *systemAccess) addQuery(query Query) {
+	isZeroCopy := query.getOptions().isZeroCopy
+	for _, componentInfo := range query.getComponentInfos() {
+		key := newAccessKey(accessKindComponent, query.TargetWorld(), componentInfo.id.componentType)
+		if componentInfo.isPointer || isZeroCopy {
+			access.write(key)
+		} else {
+			access.read(key)
+		}
+	}
+
+	// Added and Changed filters read the ticks of their component, which are written by queries with pointer components.
+	for _, filter := range query.getOptions().Filters {
+		for _, componentId := range changeFilterComponents(filter) {
+			access.read(newAccessKey(accessKindComponent, query.TargetWorld(), componentId.componentType))
+		}
+	}
+}
+
+// changeFilterComponents returns the components of the [Added] and [Changed] filters in filter.
+func changeFilterComponents(filter QueryFilter) []ComponentId {
+	switch filter := filter.(type) {
+	case *queryFilterAdded:
+		return []ComponentId{filter.c}
+	case *queryFilterChanged:
+		return []ComponentId{filter.c}
+	case *queryFilterAnd:
+		return append(changeFilterComponents(filter.a), changeFilterComponents(filter.b)...)
+	case *queryFilterOr:
+		return append(changeFilterComponents(filter.a), changeFilterComponents(filter.b)...)
+	default:
+		return nil
+	}
+}
+
+// addResource adds a resource. Resources that are used by pointer are written to, the others are read.
+func (access *systemAccess) addResource(worldId *WorldId, resourceType reflect.Type) {
+	key := newAccessKey(accessKindResource, worldId, resourceType)
+	if resourceType.Kind() == reflect.Pointer {
+		access.write(key)
+	} else {
+		access.read(key)
+	}
+}
+
+// conflictsWith returns whether the systems of access and other can not run in parallel, because either one of
+// them writes data that the other one reads or writes.
+func (access *systemAccess) conflictsWith(other *systemAccess) bool {
+	if access.isExclusive || other.isExclusive {
+		return true
+	}
+
+	for key := range access.writes {
+		if _, isRead := other.reads[key]; isRead {
+			return true
+		}
+		if _, isWritten := other.writes[key]; isWritten {
+			return true
+		}
+	}
+
+	for key := range other.writes {
+		if _, isRead := access.reads[key]; isRead {
+			return true
+		}
+	}
+
+	return false
+}
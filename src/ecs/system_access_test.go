@@ -0,0 +1,279 @@
+package ecs
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemAccess(t *testing.T) {
+	type componentA struct{ Component }
+	type componentB struct{ Component }
+	type resourceA struct{}
+	type eventA struct{ Event }
+
+	testCases := []struct {
+		name          string
+		systemA       System
+		systemB       System
+		wantConflicts bool
+	}{
+		{
+			name:          "systems without params do not conflict",
+			systemA:       func() {},
+			systemB:       func() {},
+			wantConflicts: false,
+		},
+		{
+			name:          "reading the same component does not conflict",
+			systemA:       func(_ *Query1[componentA, Default]) {},
+			systemB:       func(_ *Query2[componentA, componentB, Default]) {},
+			wantConflicts: false,
+		},
+		{
+			name:          "writing a component conflicts with reading it",
+			systemA:       func(_ *Query1[*componentA, Default]) {},
+			systemB:       func(_ *Query1[componentA, Default]) {},
+			wantConflicts: true,
+		},
+		{
+			name:          "writing a component conflicts with writing it",
+			systemA:       func(_ *Query1[*componentA, Default]) {},
+			systemB:       func(_ *Query1[*componentA, Default]) {},
+			wantConflicts: true,
+		},
+		{
+			name:          "writing different components does not conflict",
+			systemA:       func(_ *Query1[*componentA, Default]) {},
+			systemB:       func(_ *Query1[*componentB, Default]) {},
+			wantConflicts: false,
+		},
+		{
+			name:          "zero-copy queries write their components because of chunks",
+			systemA:       func(_ *Query1[componentA, ZeroCopy]) {},
+			systemB:       func(_ *Query1[componentA, Default]) {},
+			wantConflicts: true,
+		},
+		{
+			name:          "filtering on changes of a component conflicts with writing it",
+			systemA:       func(_ *Query0[Changed[componentA]]) {},
+			systemB:       func(_ *Query1[*componentA, Default]) {},
+			wantConflicts: true,
+		},
+		{
+			name:          "reading the same resource does not conflict",
+			systemA:       func(_ resourceA) {},
+			systemB:       func(_ resourceA) {},
+			wantConflicts: false,
+		},
+		{
+			name:          "writing a resource conflicts with reading it",
+			systemA:       func(_ *resourceA) {},
+			systemB:       func(_ resourceA) {},
+			wantConflicts: true,
+		},
+		{
+			name:          "reading the same event conflicts",
+			systemA:       func(_ *EventReader[*eventA]) {},
+			systemB:       func(_ *EventReader[*eventA]) {},
+			wantConflicts: true,
+		},
+		{
+			name:          "reading and writing the same event does not conflict",
+			systemA:       func(_ *EventReader[*eventA]) {},
+			systemB:       func(_ *EventWriter[*eventA]) {},
+			wantConflicts: false,
+		},
+		{
+			name:          "world conflicts with everything",
+			systemA:       func(_ *World) {},
+			systemB:       func() {},
+			wantConflicts: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			world := NewDefaultWorld()
+			err := world.Resources().Add(&resourceA{})
+			assert.NoError(err)
+			eventStorage := NewEventStorage()
+			scheduleSystems := ScheduleSystems{}
+
+			err = scheduleSystems.add(Systems(tc.systemA, tc.systemB), "", world, nil, &NoOpLogger{}, &eventStorage)
+			assert.NoError(err)
+
+			systems := scheduleSystems.getSystems()
+			assert.Len(systems, 2)
+			assert.Equal(tc.wantConflicts, systems[0].access.conflictsWith(&systems[1].access))
+			assert.Equal(tc.wantConflicts, systems[1].access.conflictsWith(&systems[0].access))
+		})
+	}
+}
+
+func TestExecParallel(t *testing.T) {
+	type componentA struct{ Component }
+
+	setup := func(assert *assert.Assertions, systems ...System) (*ScheduleSystems, *World, *EventStorage) {
+		world := NewDefaultWorld()
+		eventStorage := NewEventStorage()
+		scheduleSystems := ScheduleSystems{}
+
+		for _, system := range systems {
+			err := scheduleSystems.add(system, "", world, nil, &NoOpLogger{}, &eventStorage)
+			assert.NoError(err)
+		}
+
+		return &scheduleSystems, world, &eventStorage
+	}
+
+	t.Run("runs systems that do not conflict in parallel", func(t *testing.T) {
+		assert := assert.New(t)
+
+		// both systems wait for each other, which only succeeds if they run at the same time
+		started := make(chan struct{}, 2)
+		waitForOther := func() error {
+			started <- struct{}{}
+			timeout := time.After(time.Second)
+			for len(started) < 2 {
+				select {
+				case <-timeout:
+					return errors.New("other system did not start")
+				default:
+					time.Sleep(time.Millisecond)
+				}
+			}
+			return nil
+		}
+
+		scheduleSystems, world, eventStorage := setup(assert,
+			func(_ *Query1[componentA, Default]) error { return waitForOther() },
+			func(_ *Query1[componentA, Default]) error { return waitForOther() },
+		)
+		world.parallelWorkers = 2
+
+		errs := scheduleSystems.ExecParallel(world, nil, eventStorage, 0)
+		assert.Empty(errs)
+	})
+
+	t.Run("runs conflicting systems in the order they got added", func(t *testing.T) {
+		assert := assert.New(t)
+
+		order := []int{}
+		scheduleSystems, world, eventStorage := setup(assert,
+			func(_ *Query1[*componentA, Default]) { time.Sleep(10 * time.Millisecond); order = append(order, 1) },
+			func(_ *Query1[componentA, Default]) { order = append(order, 2) },
+			func(_ *Query1[*componentA, Default]) { order = append(order, 3) },
+		)
+
+		errs := scheduleSystems.ExecParallel(world, nil, eventStorage, 0)
+		assert.Empty(errs)
+		assert.Equal([]int{1, 2, 3}, order)
+	})
+
+	t.Run("does not run systems that write the same component through chunks at the same time", func(t *testing.T) {
+		assert := assert.New(t)
+
+		running := atomic.Int32{}
+		maxRunning := atomic.Int32{}
+		numberOfChunks := atomic.Int32{}
+		system := func(query *Query1[componentA, ZeroCopy]) error {
+			return query.IterChunks(func(entityIds []EntityId, a []componentA) {
+				current := running.Add(1)
+				for {
+					previous := maxRunning.Load()
+					if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				numberOfChunks.Add(1)
+				running.Add(-1)
+			})
+		}
+
+		scheduleSystems, world, eventStorage := setup(assert, system, system, system)
+		_, err := Spawn(world, &componentA{})
+		assert.NoError(err)
+		world.parallelWorkers = 3
+
+		errs := scheduleSystems.ExecParallel(world, nil, eventStorage, 0)
+		assert.Empty(errs)
+		assert.Equal(int32(3), numberOfChunks.Load())
+		assert.Equal(int32(1), maxRunning.Load())
+	})
+
+	t.Run("runs chained systems in order", func(t *testing.T) {
+		assert := assert.New(t)
+
+		order := []int{}
+		scheduleSystems, world, eventStorage := setup(assert, Systems(
+			func() { time.Sleep(10 * time.Millisecond); order = append(order, 1) },
+			func() { time.Sleep(5 * time.Millisecond); order = append(order, 2) },
+			func() { order = append(order, 3) },
+		).Chain())
+
+		errs := scheduleSystems.ExecParallel(world, nil, eventStorage, 0)
+		assert.Empty(errs)
+		assert.Equal([]int{1, 2, 3}, order)
+		assert.Equal([][]int{{}, {0}, {1}}, scheduleSystems.getSystemDependencies())
+	})
+
+	t.Run("does not exceed the number of parallel workers", func(t *testing.T) {
+		assert := assert.New(t)
+
+		running := atomic.Int32{}
+		maxRunning := atomic.Int32{}
+		system := func() {
+			current := running.Add(1)
+			for {
+				previous := maxRunning.Load()
+				if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		}
+
+		scheduleSystems, world, eventStorage := setup(assert, system, system, system, system, system)
+		world.parallelWorkers = 2
+
+		errs := scheduleSystems.ExecParallel(world, nil, eventStorage, 0)
+		assert.Empty(errs)
+		assert.LessOrEqual(maxRunning.Load(), int32(2))
+	})
+
+	t.Run("returns errors in the order the systems got added", func(t *testing.T) {
+		assert := assert.New(t)
+
+		errA := errors.New("a")
+		errB := errors.New("b")
+		scheduleSystems, world, eventStorage := setup(assert,
+			func() error { time.Sleep(10 * time.Millisecond); return errA },
+			func() {},
+			func() error { return errB },
+		)
+
+		errs := scheduleSystems.ExecParallel(world, nil, eventStorage, 0)
+		assert.Len(errs, 2)
+		assert.ErrorIs(errs[0], errA)
+		assert.ErrorIs(errs[1], errB)
+	})
+
+	t.Run("recomputes dependencies after adding a system", func(t *testing.T) {
+		assert := assert.New(t)
+
+		scheduleSystems, world, eventStorage := setup(assert, func(_ *World) {})
+		assert.Equal([][]int{{}}, scheduleSystems.getSystemDependencies())
+
+		err := scheduleSystems.add(func() {}, "", world, nil, &NoOpLogger{}, eventStorage)
+		assert.NoError(err)
+		assert.Equal([][]int{{}, {0}}, scheduleSystems.getSystemDependencies())
+	})
+}
@@ -0,0 +1,41 @@
+package ecs
+
+// Transfer moves entity from one world to another. The entity is despawned in from and spawned in to with the same
+// components, of which the component ids are remapped to the component ids of to. Returns the id of the entity in to.
+//
+// OnDespawn observers are triggered in from and OnSpawn observers are triggered in to. Components that point to
+// other entities of from are not transferred, see [CloneInto].
+//
+// Transfer locks the Mutex of both worlds in the same order in which schedules lock their world and outer worlds, so
+// that it can not deadlock with concurrent transfers or with schedules that have either world as an outer world. This
+// also means that Transfer must not be called while either world is locked, such as from within a system of either
+// world.
+//
+// Can return the following errors:
+//   - Returns an ErrTransferToSameWorld error when from and to are the same world
+//   - Returns an ErrEntityNotFound error when the given entity does not exist in from
+//   - Returns an ErrEntityStale error when the given entity has been despawned
+//   - Returns an ErrWorldIsLocked error while querying either world
+func Transfer(from *World, to *World, entity EntityId) (EntityId, error) {
+	if from == to {
+		return nonExistingEntity, ErrTransferToSameWorld
+	}
+
+	defer lockWorlds(from, to)()
+
+	// Check everything that can fail before making any changes, so that the entity is never in both or in
+	// neither of the worlds.
+	if from.isQuerying() || to.isQuerying() {
+		return nonExistingEntity, ErrWorldIsLocked
+	}
+	if _, err := from.entities.get(entity); err != nil {
+		return nonExistingEntity, err
+	}
+
+	transferred, err := cloneInto(from, to, entity)
+	if err != nil {
+		return transferred, err
+	}
+
+	return transferred, Despawn(from, entity)
+}
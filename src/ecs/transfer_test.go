@@ -0,0 +1,175 @@
+package ecs
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransfer(t *testing.T) {
+	type componentA struct {
+		Component
+		value int
+	}
+	type componentB struct{ Component }
+
+	t.Run("moves the entity to the other world", func(t *testing.T) {
+		assert := assert.New(t)
+		from := NewDefaultWorld()
+		to := NewDefaultWorld()
+
+		// register the components in a different order so that their ids differ between the worlds
+		_, err := Spawn(to, &componentB{})
+		assert.NoError(err)
+
+		entity, err := Spawn(from, &componentA{value: 1}, &componentB{})
+		assert.NoError(err)
+
+		transferred, err := Transfer(from, to, entity)
+		assert.NoError(err)
+		assert.Equal(0, from.CountEntities())
+		assert.Equal(2, to.CountEntities())
+
+		_, err = from.entities.get(entity)
+		assert.ErrorIs(err, ErrEntityStale)
+
+		a, _, err := Get2[componentA, componentB](to, transferred)
+		assert.NoError(err)
+		assert.Equal(1, a.value)
+	})
+
+	t.Run("triggers observers in both worlds", func(t *testing.T) {
+		assert := assert.New(t)
+		from := NewDefaultWorld()
+		to := NewDefaultWorld()
+
+		entity, err := Spawn(from, &componentA{})
+		assert.NoError(err)
+
+		despawned := []EntityId{}
+		assert.NoError(On[OnDespawn[componentA]](from, func(event OnDespawn[componentA]) {
+			despawned = append(despawned, event.Entity)
+		}))
+		spawned := []EntityId{}
+		assert.NoError(On[OnSpawn[componentA]](to, func(event OnSpawn[componentA]) {
+			spawned = append(spawned, event.Entity)
+		}))
+
+		transferred, err := Transfer(from, to, entity)
+		assert.NoError(err)
+		assert.Equal([]EntityId{entity}, despawned)
+		assert.Equal([]EntityId{transferred}, spawned)
+	})
+
+	t.Run("does not change anything when the transfer is not possible", func(t *testing.T) {
+		assert := assert.New(t)
+		from := NewDefaultWorld()
+		to := NewDefaultWorld()
+
+		entity, err := Spawn(from, &componentA{})
+		assert.NoError(err)
+
+		_, err = Transfer(from, from, entity)
+		assert.ErrorIs(err, ErrTransferToSameWorld)
+
+		_, err = Transfer(from, to, nonExistingEntity)
+		assert.ErrorIs(err, ErrEntityNotFound)
+
+		to.startQuerying()
+		_, err = Transfer(from, to, entity)
+		assert.ErrorIs(err, ErrWorldIsLocked)
+		to.stopQuerying()
+
+		assert.Equal(1, from.CountEntities())
+		assert.Equal(0, to.CountEntities())
+	})
+
+	t.Run("concurrent transfers in opposite directions do not deadlock", func(t *testing.T) {
+		assert := assert.New(t)
+		worldA := NewDefaultWorld()
+		worldB := NewDefaultWorld()
+
+		const numberOfEntities = 100
+		entitiesA, err := SpawnBatch(worldA, numberOfEntities, &componentA{})
+		assert.NoError(err)
+		entitiesB, err := SpawnBatch(worldB, numberOfEntities, &componentA{})
+		assert.NoError(err)
+
+		var waitGroup sync.WaitGroup
+		waitGroup.Go(func() {
+			for _, entity := range entitiesA {
+				_, err := Transfer(worldA, worldB, entity)
+				assert.NoError(err)
+			}
+		})
+		waitGroup.Go(func() {
+			for _, entity := range entitiesB {
+				_, err := Transfer(worldB, worldA, entity)
+				assert.NoError(err)
+			}
+		})
+		waitGroup.Wait()
+
+		assert.Equal(numberOfEntities, worldA.CountEntities())
+		assert.Equal(numberOfEntities, worldB.CountEntities())
+	})
+
+	t.Run("transfers do not deadlock with a schedule that has the other world as outer world", func(t *testing.T) {
+		assert := assert.New(t)
+		worldA := NewDefaultWorld()
+		worldB := NewDefaultWorld()
+		if uintptr(unsafe.Pointer(worldB)) > uintptr(unsafe.Pointer(worldA)) {
+			// the outer world must be the one with the lowest address to detect locking in a different order
+			worldA, worldB = worldB, worldA
+		}
+		outerWorlds := map[WorldId]*World{TestCustomTargetWorldId: worldB}
+		logger := NoOpLogger{}
+		eventStorage := NewEventStorage()
+
+		scheduleSystems := ScheduleSystems{}
+		err := scheduleSystems.add(func() {}, "", worldA, &outerWorlds, &logger, &eventStorage)
+		assert.NoError(err)
+		assert.NoError(scheduleSystems.prepare(&outerWorlds))
+
+		const numberOfEntities = 1000
+		entitiesA, err := SpawnBatch(worldA, numberOfEntities, &componentA{})
+		assert.NoError(err)
+		entitiesB, err := SpawnBatch(worldB, numberOfEntities, &componentA{})
+		assert.NoError(err)
+
+		done := make(chan struct{})
+		go func() {
+			var waitGroup sync.WaitGroup
+			var isTransferred atomic.Bool
+			waitGroup.Go(func() {
+				for tick := uint(1); !isTransferred.Load(); tick++ {
+					assert.Empty(scheduleSystems.Exec(worldA, &outerWorlds, &eventStorage, tick))
+				}
+			})
+			waitGroup.Go(func() {
+				for i := range numberOfEntities {
+					_, err := Transfer(worldA, worldB, entitiesA[i])
+					assert.NoError(err)
+					_, err = Transfer(worldB, worldA, entitiesB[i])
+					assert.NoError(err)
+				}
+				isTransferred.Store(true)
+			})
+			waitGroup.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			assert.FailNow("deadlock")
+		}
+
+		assert.Equal(numberOfEntities, worldA.CountEntities())
+		assert.Equal(numberOfEntities, worldB.CountEntities())
+	})
+}
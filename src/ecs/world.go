@@ -1,10 +1,14 @@
 package ecs
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type WorldId int
@@ -13,8 +17,7 @@ type WorldId int
 type World struct {
 	id *WorldId // setting an id is optional
 
-	entityIdCounter   uint
-	entities          map[EntityId]*EntityData
+	entities          entityStorage
 	componentRegistry componentRegistry
 	archetypeStorage  archetypeStorage
 
@@ -30,10 +33,24 @@ type World struct {
 	logger                   Logger
 	scheduleSystemsIdCounter ScheduleSystemsId
 	currentScheduleSystemsId ScheduleSystemsId // set to the running schedule's id during Exec, 0 otherwise
+	currentTick              uint              // the tick of the last Exec of any schedule
+	scheduleChangeTick       uint32            // the change tick before the last Exec of any schedule gave its systems their change ticks
+	removedComponents        removedComponentsStorage
+	prefabs                  prefabRegistry
 
 	Mutex sync.RWMutex
 
-	isQuerying bool
+	// The number of queries that are currently being iterated. Structural changes are not allowed while
+	// this is not 0. Multiple queries may be iterated concurrently by systems that run in parallel.
+	queryDepth atomic.Int32
+
+	parallelWorkers int // 0 means runtime.GOMAXPROCS
+
+	// The number of times that the change tick got advanced. See [World.ChangeTick].
+	changeTickAdvances atomic.Uint32
+
+	// The change tick at which the stored change ticks got clamped last. See [World.checkChangeTicks].
+	lastChangeTickCheck uint32
 }
 
 // NewDefaultWorld returns a World with default configs.
@@ -65,7 +82,7 @@ func NewWorld(configs WorldConfigs) (World, error) {
 	}
 
 	return World{
-		entities:                         map[EntityId]*EntityData{},
+		entities:                         newEntityStorage(),
 		id:                               configs.Id,
 		initialComponentCapacityStrategy: configs.InitialComponentCapacityStrategy,
 		componentCapacityGrowthStrategy:  configs.ComponentCapacityGrowthStrategy,
@@ -73,13 +90,44 @@ func NewWorld(configs WorldConfigs) (World, error) {
 		archetypeStorage:                 newArchetypeStorage(),
 		resources:                        newResourceStorage(),
 		observers:                        newObserverRegistry(),
+		removedComponents:                newRemovedComponentsStorage(),
+		prefabs:                          newPrefabRegistry(),
 		events:                           NewEventStorage(),
 		scheduler:                        newScheduler(),
 		outerWorlds:                      map[WorldId]*World{},
 		logger:                           logger,
+		parallelWorkers:                  configs.ParallelWorkers,
 	}, nil
 }
 
+// isQuerying returns whether any query is being iterated.
+func (world *World) isQuerying() bool {
+	return world.queryDepth.Load() > 0
+}
+
+func (world *World) startQuerying() {
+	world.queryDepth.Add(1)
+}
+
+func (world *World) stopQuerying() {
+	world.queryDepth.Add(-1)
+}
+
+// ChangeTick returns the current change tick of the world. Components keep track of the change tick at which
+// they got added and changed, which is used by the [Added] and [Changed] query filters. The change tick is
+// advanced every time a system runs, and every time a query that is not a system param gets executed.
+//
+// The change tick starts at 1, so that 0 can be used as the tick of systems that did not run yet. It wraps around
+// after reaching the maximum uint32, which change detection takes into account.
+func (world *World) ChangeTick() uint32 {
+	return world.changeTickAdvances.Load() + 1
+}
+
+// advanceChangeTick increases the change tick and returns the new change tick.
+func (world *World) advanceChangeTick() uint32 {
+	return world.changeTickAdvances.Add(1) + 1
+}
+
 // Process should be called on a regular basis (such as every tick).
 //
 // ! This call is NOT concurrency safe !
@@ -88,7 +136,7 @@ func (world *World) Process() {
 }
 
 func (world *World) CountEntities() int {
-	return len(world.entities)
+	return world.entities.numberOfEntities
 }
 
 func (world *World) CountComponents() int {
@@ -99,11 +147,6 @@ func (world *World) CountArchetypes() int {
 	return len(world.archetypeStorage.componentsHashToArchetype)
 }
 
-func (world *World) generateEntityId() EntityId {
-	world.entityIdCounter++
-	return EntityId(world.entityIdCounter)
-}
-
 func (world *World) Id() *WorldId {
 	return world.id
 }
@@ -156,6 +199,27 @@ func (world *World) SetSchedulePaused(schedule Schedule, isPaused bool) error {
 	return nil
 }
 
+// lockWorlds locks the Mutex of each of worlds and returns a function that unlocks them. Worlds are always locked
+// in the order of their address, so that goroutines that lock overlapping sets of worlds, such as schedules with
+// outer worlds and [Transfer], can not deadlock. A world that is given multiple times is locked once.
+func lockWorlds(worlds ...*World) (unlock func()) {
+	worlds = slices.Clone(worlds)
+	slices.SortFunc(worlds, func(a, b *World) int {
+		return cmp.Compare(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)))
+	})
+	worlds = slices.Compact(worlds)
+
+	for _, world := range worlds {
+		world.Mutex.Lock()
+	}
+
+	return func() {
+		for i := len(worlds) - 1; i >= 0; i-- {
+			worlds[i].Mutex.Unlock()
+		}
+	}
+}
+
 // RegisterOuterWorld lets systems query components and resources from another world.
 func (world *World) RegisterOuterWorld(id WorldId, other *World) error {
 	if _, exists := world.outerWorlds[id]; exists {
@@ -11,6 +11,10 @@ type WorldConfigs struct {
 
 	// Logger is optional. Defaults to [NoOpLogger] if nil.
 	Logger Logger
+
+	// ParallelWorkers is the maximum number of goroutines that are used by parallel query iteration, such as
+	// [Query1.ParIter], and by [ScheduleSystems.ExecParallel]. Defaults to runtime.GOMAXPROCS if 0.
+	ParallelWorkers int
 }
 
 func DefaultWorldConfigs() WorldConfigs {
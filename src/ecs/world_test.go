@@ -53,16 +53,6 @@ func TestCreateWorld(t *testing.T) {
 	})
 }
 
-func TestGenerateEntityId(t *testing.T) {
-	assert := assert.New(t)
-
-	world := NewDefaultWorld()
-	entity1 := world.generateEntityId()
-	entity2 := world.generateEntityId()
-
-	assert.NotEqual(entity1, entity2)
-}
-
 func TestStats(t *testing.T) {
 	t.Run("world returns the correct stats after inserting", func(t *testing.T) {
 		assert := assert.New(t)